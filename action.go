@@ -3,9 +3,14 @@ package telegram
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
+	"net/url"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
 )
 
 // Action represents a message action to execute
@@ -16,6 +21,8 @@ type Action struct {
 	User     ActionUser `json:"user,omitempty"`     // User info (TgID required)
 	Content  Content    `json:"content,omitempty"`  // Message content
 	Token    string     `json:"-"`                  // Bot token (passed separately)
+
+	CorrelationID string `json:"correlation_id,omitempty"` // Caller ID copied to ActionResult
 }
 
 // ActionUser represents user information for action
@@ -26,16 +33,28 @@ type ActionUser struct {
 
 // Content represents the message content
 type Content struct {
-	Type        string                 `json:"type,omitempty"`         // text, inline_keyboard, virtual_keyboard, sticker, dice, etc.
-	Stream      string                 `json:"stream,omitempty"`       // tg_direct
-	Text        string                 `json:"text,omitempty"`         // Message text
-	Attachment  *Attachment            `json:"attachment,omitempty"`   // Media attachment
-	Buts        []string               `json:"buts,omitempty"`         // Button labels
-	Actions     []json.RawMessage      `json:"actions,omitempty"`      // Button callback actions
-	ReplyMarkup map[string]interface{} `json:"reply_markup,omitempty"` // Custom reply markup
-	ColumnNum   *int                   `json:"column_num,omitempty"`   // Keyboard column count
-	Spices      map[string]interface{} `json:"spices,omitempty"`       // Extra params (parse_mode, etc.)
-	Parameters  Parameters             `json:"parameters,omitempty"`   // Action parameters
+	Type         string                 `json:"type,omitempty"`          // text, inline_keyboard, virtual_keyboard, sticker, dice, etc.
+	Stream       string                 `json:"stream,omitempty"`        // tg_direct
+	Text         string                 `json:"text,omitempty"`          // Message text
+	Attachment   *Attachment            `json:"attachment,omitempty"`    // Media attachment
+	Attachments  []Attachment           `json:"attachments,omitempty"`   // media_group album items
+	Buts         []string               `json:"buts,omitempty"`          // Button labels
+	Actions      []json.RawMessage      `json:"actions,omitempty"`       // Button callback actions
+	ReplyMarkup  map[string]interface{} `json:"reply_markup,omitempty"`  // Custom reply markup
+	ColumnNum    *int                   `json:"column_num,omitempty"`    // Keyboard column count
+	ReplyButtons []KeyboardButton       `json:"reply_buttons,omitempty"` // Typed virtual_keyboard buttons, used instead of Buts
+	Keyboard     *KeyboardOptions       `json:"keyboard,omitempty"`      // virtual_keyboard options
+	Spices       map[string]interface{} `json:"spices,omitempty"`        // Extra params (parse_mode, etc.)
+	Parameters   Parameters             `json:"parameters,omitempty"`    // Action parameters
+}
+
+// KeyboardOptions configures a reply keyboard generated for virtual_keyboard content
+// ResizeKeyboard and OneTimeKeyboard default to true when not set
+type KeyboardOptions struct {
+	ResizeKeyboard        *bool  `json:"resize_keyboard,omitempty"`
+	OneTimeKeyboard       *bool  `json:"one_time_keyboard,omitempty"`
+	Selective             bool   `json:"selective,omitempty"`
+	InputFieldPlaceholder string `json:"input_field_placeholder,omitempty"`
 }
 
 // Attachment represents media attachment
@@ -48,12 +67,15 @@ type Attachment struct {
 	Poll          interface{} `json:"poll,omitempty"`            // Poll data
 	Venue         interface{} `json:"venue,omitempty"`           // Venue data
 	GameShortName string      `json:"game_short_name,omitempty"` // Game short name
+	Length        int         `json:"length,omitempty"`          // video_note diameter, 240 by default
 }
 
 // Parameters represents action parameters
 type Parameters struct {
 	Save         *bool   `json:"save,omitempty"`          // Save to outbox
 	SendReaction *string `json:"send_reaction,omitempty"` // Chat action before send
+	ReactAfter   *string `json:"react_after,omitempty"`   // Emoji reaction on the sent message
+	ReactStrict  *bool   `json:"react_strict,omitempty"`  // Fail the action if ReactAfter fails
 }
 
 // ActionResult represents the result of action execution
@@ -62,6 +84,29 @@ type ActionResult struct {
 	MessageID int64     `json:"message_id,omitempty"`
 	Response  *Response `json:"response,omitempty"`
 	Error     error     `json:"error,omitempty"`
+
+	// MessageIDs holds IDs of all sent messages, every message of an album for media_group
+	// MessageID and Response are of the first one
+	MessageIDs []int64 `json:"message_ids,omitempty"`
+
+	CorrelationID string    `json:"correlation_id,omitempty"` // From Action or context
+	StartedAt     time.Time `json:"started_at"`               // When the send started
+	CompletedAt   time.Time `json:"completed_at"`             // When Telegram responded
+}
+
+// correlationIDKey is the context key for correlation ID
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID for ExecuteAction
+// Action.CorrelationID takes precedence over the context value
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored by WithCorrelationID
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
 }
 
 // CallbackData represents callback query data for keyboard buttons
@@ -78,68 +123,251 @@ type CallbackSaver interface {
 	SaveCallbackDataBatch(ctx context.Context, data []*CallbackData) error
 }
 
+// CallbackResolver interface for looking up callback data saved by CallbackSaver
+// ResolveCallbackData returns nil data or ErrCallbackDataNotFound when nothing is stored
+type CallbackResolver interface {
+	ResolveCallbackData(ctx context.Context, queryData string) (*CallbackData, error)
+}
+
+// HandleCallback resolves the callback data of a button generated by ExecuteAction
+// The callback query still has to be answered with AnswerCallbackQuery
+func (c *Client) HandleCallback(ctx context.Context, cq *CallbackQuery, r CallbackResolver) (*CallbackData, error) {
+	if cq == nil || cq.Data == "" {
+		return nil, ErrCallbackDataNotFound
+	}
+
+	data, err := r.ResolveCallbackData(ctx, cq.Data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrCallbackDataNotFound
+	}
+	return data, nil
+}
+
 // ExecuteAction executes a message action using tgbotapi
-// Returns ActionResult with message ID on success or error on failure
+// Returns ActionResult with message ID and the sent message in Response on success,
+// or with Error on failure. Unsupported streams and content types are errors too
 func (c *Client) ExecuteAction(ctx context.Context, action *Action, callbackSaver CallbackSaver) (*ActionResult, error) {
+	result := &ActionResult{
+		CorrelationID: action.CorrelationID,
+		StartedAt:     time.Now(),
+	}
+	if result.CorrelationID == "" {
+		result.CorrelationID = CorrelationIDFromContext(ctx)
+	}
+
 	if action.Content.Stream != "tg_direct" && action.Content.Stream != "" {
 		// Only tg_direct stream is supported
-		return &ActionResult{Success: false}, nil
+		result.Error = fmt.Errorf("%w: %q", ErrUnsupportedStream, action.Content.Stream)
+		result.CompletedAt = time.Now()
+		return result, result.Error
 	}
 
 	if err := c.initBot(); err != nil {
-		return &ActionResult{Success: false, Error: err}, err
+		result.Error = err
+		result.CompletedAt = time.Now()
+		return result, err
 	}
 
-	// Apply text formatting
-	text := action.Content.Text
-	parseMode := ""
-	if pm, ok := action.Content.Spices["parse_mode"].(string); ok {
-		parseMode = pm
-		if parseMode == "MarkdownV2" {
-			text = FormatMarkdownV2(text)
-		}
+	// Apply text formatting, see formatText
+	parseMode, _ := action.Content.Spices["parse_mode"].(string)
+	if err := validateParseMode(parseMode); err != nil {
+		result.Error = err
+		result.CompletedAt = time.Now()
+		return result, err
 	}
+	text := formatText(action.Content.Text, parseMode)
 
 	// Send chat action if configured
 	if action.Content.Parameters.SendReaction != nil {
 		chatAction := tgbotapi.NewChatAction(action.User.TgID, *action.Content.Parameters.SendReaction)
-		_, _ = c.bot.Request(chatAction)
+		_, _ = c.botFor(ctx).Request(chatAction)
 	}
 
 	// Build and send message based on content type
-	var sent tgbotapi.Message
+	var sent sentMessage
 	var err error
 
 	switch action.Content.Type {
 	case "sticker":
-		sent, err = c.sendStickerAction(action)
+		sent, err = c.sendStickerAction(ctx, action)
 	case "dice":
-		sent, err = c.sendDiceAction(action)
+		sent, err = c.sendDiceAction(ctx, action)
 	case "contact":
-		sent, err = c.sendContactAction(action)
+		sent, err = c.sendContactAction(ctx, action)
 	case "poll":
-		sent, err = c.sendPollAction(action, parseMode)
+		sent, err = c.sendPollAction(ctx, action, parseMode)
 	case "game":
-		sent, err = c.sendGameAction(action)
+		sent, err = c.sendGameAction(ctx, action)
 	case "venue":
-		sent, err = c.sendVenueAction(action)
-	default:
-		// Text-based messages (text, inline_keyboard, virtual_keyboard, or empty)
+		sent, err = c.sendVenueAction(ctx, action)
+	case "media_group":
+		var album []sentMessage
+		if album, err = c.sendMediaGroupAction(ctx, action, text, parseMode); err == nil {
+			sent = album[0]
+			for _, m := range album {
+				result.MessageIDs = append(result.MessageIDs, int64(m.MessageID))
+			}
+		}
+	case "", "text", "inline_keyboard", "virtual_keyboard":
 		sent, err = c.sendTextBasedAction(ctx, action, text, parseMode, callbackSaver)
+	default:
+		// Content with an attachment, e.g. of type "photo", is sent by the attachment type
+		if action.Content.Attachment != nil && action.Content.Attachment.URL != "" {
+			sent, err = c.sendMediaAction(ctx, action, text, parseMode, callbackSaver)
+		} else {
+			err = fmt.Errorf("%w: %q", ErrUnsupportedContentType, action.Content.Type)
+		}
 	}
 
+	result.CompletedAt = time.Now()
 	if err != nil {
-		return &ActionResult{Success: false, Error: err}, err
+		err = redactError(err, c.tokens())
+		result.Error = err
+		return result, err
+	}
+	result.MessageID = int64(sent.MessageID)
+	if result.MessageIDs == nil {
+		result.MessageIDs = []int64{result.MessageID}
+	}
+	if raw, err := json.Marshal(sent); err == nil {
+		result.Response = &Response{OK: true, Result: raw}
+	}
+
+	// React to the sent message if configured
+	if reaction := action.Content.Parameters.ReactAfter; reaction != nil && sent.MessageID != 0 {
+		if err := c.setMessageReaction(ctx, action.User.TgID, int64(sent.MessageID), []ReactionType{ReactionEmoji(*reaction)}, false); err != nil {
+			if strict := action.Content.Parameters.ReactStrict; strict != nil && *strict {
+				result.Error = err
+				return result, err
+			}
+			if c.logger != nil {
+				c.logger.Warn("failed to react to sent message",
+					zap.Int64("chat_id", action.User.TgID),
+					zap.Int("message_id", sent.MessageID),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	result.Success = true
+	return result, nil
+}
+
+// MessageToAction converts a received message into an Action that sends it to targetTgID
+// The conversion is lossy: entities, forward origin, reply target, captions of
+// stickers and other metadata are not recreated. Inline keyboards are rebuilt
+// from ReplyMarkup, callback buttons get new callback data when the action is
+// executed while buttons of other kinds are kept as is
+func MessageToAction(msg *Message, targetTgID int64) (*Action, error) {
+	if msg == nil {
+		return nil, errors.New("message is nil")
 	}
 
-	return &ActionResult{
-		Success:   true,
-		MessageID: int64(sent.MessageID),
-	}, nil
+	action := &Action{
+		Activity: "message",
+		User:     ActionUser{TgID: targetTgID},
+		Content: Content{
+			Type:   "text",
+			Stream: "tg_direct",
+			Text:   msg.Text,
+		},
+	}
+
+	// Copies of protected content stay protected
+	if msg.HasProtectedContent {
+		action.Content.Spices = map[string]interface{}{"protect_content": true}
+	}
+
+	if len(msg.ReplyMarkup) > 0 {
+		var markup map[string]interface{}
+		if err := json.Unmarshal(msg.ReplyMarkup, &markup); err != nil {
+			return nil, fmt.Errorf("failed to decode reply markup: %w", err)
+		}
+		action.Content.ReplyMarkup = markup
+	}
+
+	content := &action.Content
+	switch {
+	case len(msg.Photo) > 0:
+		// The last size is the largest one
+		content.Text = msg.Caption
+		content.Attachment = &Attachment{Type: "photo", URL: msg.Photo[len(msg.Photo)-1].FileID}
+	case msg.Document != nil:
+		content.Text = msg.Caption
+		content.Attachment = &Attachment{Type: "document", URL: msg.Document.FileID}
+	case msg.Video != nil:
+		content.Text = msg.Caption
+		content.Attachment = &Attachment{Type: "video", URL: msg.Video.FileID}
+	case msg.Audio != nil:
+		content.Text = msg.Caption
+		content.Attachment = &Attachment{Type: "audio", URL: msg.Audio.FileID}
+	case msg.Voice != nil:
+		content.Text = msg.Caption
+		content.Attachment = &Attachment{Type: "voice", URL: msg.Voice.FileID}
+	case msg.VideoNote != nil:
+		content.Attachment = &Attachment{Type: "video_note", URL: msg.VideoNote.FileID, Length: msg.VideoNote.Length}
+	case msg.Sticker != nil:
+		content.Type = "sticker"
+		content.Attachment = &Attachment{Sticker: msg.Sticker.FileID}
+	case msg.Dice != nil:
+		content.Type = "dice"
+		content.Attachment = &Attachment{Dice: msg.Dice.Emoji}
+	case msg.Contact != nil:
+		content.Type = "contact"
+		content.Attachment = &Attachment{Contact: map[string]interface{}{
+			"phone_number": msg.Contact.PhoneNumber,
+			"first_name":   msg.Contact.FirstName,
+			"last_name":    msg.Contact.LastName,
+			"vcard":        msg.Contact.VCard,
+		}}
+	case msg.Poll != nil:
+		options := make([]interface{}, 0, len(msg.Poll.Options))
+		for _, opt := range msg.Poll.Options {
+			options = append(options, opt.Text)
+		}
+		poll := map[string]interface{}{
+			"question":                msg.Poll.Question,
+			"options":                 options,
+			"is_anonymous":            msg.Poll.IsAnonymous,
+			"type":                    msg.Poll.Type,
+			"allows_multiple_answers": msg.Poll.AllowsMultipleAnswers,
+		}
+		// Quizzes need the correct option, Telegram shares it only with the poll creator
+		if msg.Poll.Type == "quiz" {
+			poll["correct_option_id"] = msg.Poll.CorrectOptionID
+		}
+		if msg.Poll.Explanation != "" {
+			poll["explanation"] = msg.Poll.Explanation
+		}
+		content.Type = "poll"
+		content.Attachment = &Attachment{Poll: poll}
+	case msg.Venue != nil:
+		content.Type = "venue"
+		content.Attachment = &Attachment{Venue: map[string]interface{}{
+			"latitude":        msg.Venue.Location.Latitude,
+			"longitude":       msg.Venue.Location.Longitude,
+			"title":           msg.Venue.Title,
+			"address":         msg.Venue.Address,
+			"foursquare_id":   msg.Venue.FoursquareID,
+			"foursquare_type": msg.Venue.FoursquareType,
+		}}
+	case msg.Text == "":
+		return nil, errors.New("message content can't be converted to action")
+	}
+
+	return action, nil
 }
 
 // sendStickerAction sends a sticker
-func (c *Client) sendStickerAction(action *Action) (tgbotapi.Message, error) {
+func (c *Client) sendStickerAction(ctx context.Context, action *Action) (sentMessage, error) {
+	if action.Content.Attachment == nil || action.Content.Attachment.Sticker == "" {
+		return sentMessage{}, fmt.Errorf("%w: missing sticker", ErrInvalidActionPayload)
+	}
+
 	var file tgbotapi.RequestFileData
 	sticker := action.Content.Attachment.Sticker
 	if len(sticker) > 100 || (len(sticker) > 0 && sticker[0] == 'h') {
@@ -148,23 +376,30 @@ func (c *Client) sendStickerAction(action *Action) (tgbotapi.Message, error) {
 		file = tgbotapi.FileID(sticker)
 	}
 	msg := tgbotapi.NewSticker(action.User.TgID, file)
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendDiceAction sends a dice animation
-func (c *Client) sendDiceAction(action *Action) (tgbotapi.Message, error) {
+func (c *Client) sendDiceAction(ctx context.Context, action *Action) (sentMessage, error) {
 	msg := tgbotapi.NewDice(action.User.TgID)
 	if action.Content.Attachment != nil && action.Content.Attachment.Dice != "" {
 		msg.Emoji = action.Content.Attachment.Dice
 	}
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendContactAction sends a contact
-func (c *Client) sendContactAction(action *Action) (tgbotapi.Message, error) {
+func (c *Client) sendContactAction(ctx context.Context, action *Action) (sentMessage, error) {
+	if action.Content.Attachment == nil {
+		return sentMessage{}, fmt.Errorf("%w: missing contact attachment", ErrInvalidActionPayload)
+	}
 	cont, ok := action.Content.Attachment.Contact.(map[string]interface{})
 	if !ok {
-		return tgbotapi.Message{}, nil
+		return sentMessage{}, fmt.Errorf("%w: invalid contact payload", ErrInvalidActionPayload)
 	}
 
 	phoneNumber, _ := cont["phone_number"].(string)
@@ -177,14 +412,19 @@ func (c *Client) sendContactAction(action *Action) (tgbotapi.Message, error) {
 	if vcard, ok := cont["vcard"].(string); ok {
 		msg.VCard = vcard
 	}
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendPollAction sends a poll
-func (c *Client) sendPollAction(action *Action, parseMode string) (tgbotapi.Message, error) {
+func (c *Client) sendPollAction(ctx context.Context, action *Action, parseMode string) (sentMessage, error) {
+	if action.Content.Attachment == nil {
+		return sentMessage{}, fmt.Errorf("%w: missing poll attachment", ErrInvalidActionPayload)
+	}
 	poll, ok := action.Content.Attachment.Poll.(map[string]interface{})
 	if !ok {
-		return tgbotapi.Message{}, nil
+		return sentMessage{}, fmt.Errorf("%w: invalid poll payload", ErrInvalidActionPayload)
 	}
 
 	question, _ := poll["question"].(string)
@@ -208,31 +448,42 @@ func (c *Client) sendPollAction(action *Action, parseMode string) (tgbotapi.Mess
 	if allowsMultiple, ok := poll["allows_multiple_answers"].(bool); ok {
 		msg.AllowsMultipleAnswers = allowsMultiple
 	}
+	if correctOption, ok := asInt(poll["correct_option_id"]); ok {
+		msg.CorrectOptionID = int64(correctOption)
+	}
 	if explanation, ok := poll["explanation"].(string); ok {
-		if parseMode == "MarkdownV2" {
-			explanation = FormatMarkdownV2(explanation)
-		}
-		msg.Explanation = explanation
+		msg.Explanation = formatText(explanation, parseMode)
 		msg.ExplanationParseMode = parseMode
 	}
 
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendGameAction sends a game
-func (c *Client) sendGameAction(action *Action) (tgbotapi.Message, error) {
+func (c *Client) sendGameAction(ctx context.Context, action *Action) (sentMessage, error) {
+	if action.Content.Attachment == nil || action.Content.Attachment.GameShortName == "" {
+		return sentMessage{}, fmt.Errorf("%w: missing game short name", ErrInvalidActionPayload)
+	}
+
 	msg := tgbotapi.GameConfig{
 		BaseChat:      tgbotapi.BaseChat{ChatID: action.User.TgID},
 		GameShortName: action.Content.Attachment.GameShortName,
 	}
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendVenueAction sends a venue
-func (c *Client) sendVenueAction(action *Action) (tgbotapi.Message, error) {
+func (c *Client) sendVenueAction(ctx context.Context, action *Action) (sentMessage, error) {
+	if action.Content.Attachment == nil {
+		return sentMessage{}, fmt.Errorf("%w: missing venue attachment", ErrInvalidActionPayload)
+	}
 	venue, ok := action.Content.Attachment.Venue.(map[string]interface{})
 	if !ok {
-		return tgbotapi.Message{}, nil
+		return sentMessage{}, fmt.Errorf("%w: invalid venue payload", ErrInvalidActionPayload)
 	}
 
 	latitude, _ := venue["latitude"].(float64)
@@ -247,11 +498,13 @@ func (c *Client) sendVenueAction(action *Action) (tgbotapi.Message, error) {
 	if foursquareType, ok := venue["foursquare_type"].(string); ok {
 		msg.FoursquareType = foursquareType
 	}
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
 // sendTextBasedAction handles text, inline_keyboard, virtual_keyboard messages
-func (c *Client) sendTextBasedAction(ctx context.Context, action *Action, text, parseMode string, callbackSaver CallbackSaver) (tgbotapi.Message, error) {
+func (c *Client) sendTextBasedAction(ctx context.Context, action *Action, text, parseMode string, callbackSaver CallbackSaver) (sentMessage, error) {
 	chatID := action.User.TgID
 
 	// Check if there's an attachment (media message)
@@ -265,93 +518,156 @@ func (c *Client) sendTextBasedAction(ctx context.Context, action *Action, text,
 
 	// Apply reply markup
 	if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-		return tgbotapi.Message{}, err
+		return sentMessage{}, err
 	}
 
-	return c.bot.Send(msg)
+	opts := actionSendOptions(action)
+	applyBaseOptions(&msg.BaseChat, opts)
+	return c.send(ctx, msg, opts)
 }
 
-// sendMediaAction sends a media message with caption
-func (c *Client) sendMediaAction(ctx context.Context, action *Action, caption, parseMode string, callbackSaver CallbackSaver) (tgbotapi.Message, error) {
-	chatID := action.User.TgID
-	attachment := action.Content.Attachment
-
-	var baseChat tgbotapi.BaseChat
-	var sent tgbotapi.Message
-	var err error
+// actionMedia is an Attachment built for sending on its own or as an album item
+type actionMedia struct {
+	config tgbotapi.Chattable // Send config of the attachment on its own, nil for unknown types
+	base   *tgbotapi.BaseChat // BaseChat of config
+	album  interface{}        // InputMedia of the attachment, nil for types albums don't support
+}
 
+// buildActionMedia builds the attachment with the caption fitted into MaxCaptionLength, see fitCaption
+func buildActionMedia(chatID int64, attachment *Attachment, caption, parseMode string) (actionMedia, error) {
 	switch attachment.Type {
-	case "photo":
-		msg := tgbotapi.NewPhoto(chatID, tgbotapi.FileURL(attachment.URL))
-		msg.Caption = caption
-		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
+	case "photo", "document", "video", "audio", "voice":
+		var err error
+		if caption, err = fitCaption(caption, parseMode); err != nil {
+			return actionMedia{}, err
 		}
-		sent, err = c.bot.Send(msg)
+	}
 
-	case "document":
-		msg := tgbotapi.NewDocument(chatID, tgbotapi.FileURL(attachment.URL))
-		msg.Caption = caption
-		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
-		}
-		sent, err = c.bot.Send(msg)
+	file := tgbotapi.FileURL(attachment.URL)
+	input := tgbotapi.BaseInputMedia{
+		Type:      attachment.Type,
+		Media:     file,
+		Caption:   caption,
+		ParseMode: parseMode,
+	}
 
+	switch attachment.Type {
+	case "photo":
+		msg := tgbotapi.NewPhoto(chatID, file)
+		msg.Caption, msg.ParseMode = caption, parseMode
+		return actionMedia{config: &msg, base: &msg.BaseChat, album: tgbotapi.InputMediaPhoto{BaseInputMedia: input}}, nil
+	case "document":
+		msg := tgbotapi.NewDocument(chatID, file)
+		msg.Caption, msg.ParseMode = caption, parseMode
+		return actionMedia{config: &msg, base: &msg.BaseChat, album: tgbotapi.InputMediaDocument{BaseInputMedia: input}}, nil
 	case "video":
-		msg := tgbotapi.NewVideo(chatID, tgbotapi.FileURL(attachment.URL))
-		msg.Caption = caption
-		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
+		msg := tgbotapi.NewVideo(chatID, file)
+		msg.Caption, msg.ParseMode = caption, parseMode
+		return actionMedia{config: &msg, base: &msg.BaseChat, album: tgbotapi.InputMediaVideo{BaseInputMedia: input}}, nil
+	case "audio":
+		msg := tgbotapi.NewAudio(chatID, file)
+		msg.Caption, msg.ParseMode = caption, parseMode
+		return actionMedia{config: &msg, base: &msg.BaseChat, album: tgbotapi.InputMediaAudio{BaseInputMedia: input}}, nil
+	case "voice":
+		msg := tgbotapi.NewVoice(chatID, file)
+		msg.Caption, msg.ParseMode = caption, parseMode
+		return actionMedia{config: &msg, base: &msg.BaseChat}, nil
+	case "video_note":
+		length := defaultVideoNoteLength
+		if attachment.Length > 0 {
+			length = attachment.Length
 		}
-		sent, err = c.bot.Send(msg)
+		msg := tgbotapi.NewVideoNote(chatID, length, file)
+		return actionMedia{config: &msg, base: &msg.BaseChat}, nil
+	default:
+		return actionMedia{}, nil
+	}
+}
 
-	case "audio":
-		msg := tgbotapi.NewAudio(chatID, tgbotapi.FileURL(attachment.URL))
-		msg.Caption = caption
-		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
+// sendMediaGroupAction sends Attachments as an album with the text as caption of the first item
+// Albums can't have reply markup, so keyboard fields of the content are ignored.
+// Returns all messages of the album
+func (c *Client) sendMediaGroupAction(ctx context.Context, action *Action, caption, parseMode string) ([]sentMessage, error) {
+	media := make([]interface{}, 0, len(action.Content.Attachments))
+	for i := range action.Content.Attachments {
+		attachment := &action.Content.Attachments[i]
+		itemCaption, itemParseMode := "", ""
+		if i == 0 {
+			itemCaption, itemParseMode = caption, parseMode
 		}
-		sent, err = c.bot.Send(msg)
 
-	case "voice":
-		msg := tgbotapi.NewVoice(chatID, tgbotapi.FileURL(attachment.URL))
-		msg.Caption = caption
-		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
+		built, err := buildActionMedia(action.User.TgID, attachment, itemCaption, itemParseMode)
+		if err != nil {
+			return nil, fmt.Errorf("media group item #%d: %w", i, err)
+		}
+		if built.album == nil {
+			return nil, fmt.Errorf("unsupported media group attachment type: %q", attachment.Type)
 		}
-		sent, err = c.bot.Send(msg)
+		media = append(media, built.album)
+	}
+	if len(media) == 0 {
+		return nil, errors.New("media group has no attachments")
+	}
 
-	case "video_note":
-		msg := tgbotapi.NewVideoNote(chatID, 240, tgbotapi.FileURL(attachment.URL))
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
+	config := tgbotapi.NewMediaGroup(action.User.TgID, media)
+	opts := actionSendOptions(action)
+	if disableNotification, ok := opts["disable_notification"].(bool); ok {
+		config.DisableNotification = disableNotification
+	}
+	extra, err := extraParams(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent []sentMessage
+	err = c.withSender(ctx, config.ChatID, func(bot *tgbotapi.BotAPI) error {
+		resp, err := withExtraParams(bot, extra).Request(config)
+		if err != nil {
+			return err
 		}
-		sent, err = c.bot.Send(msg)
+		return json.Unmarshal(resp.Result, &sent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sent) == 0 {
+		return nil, errors.New("media group response has no messages")
+	}
+	return sent, nil
+}
 
-	default:
-		// Fallback to text message
+// sendMediaAction sends a media message with caption
+// Attachments of unknown types fall back to a text message
+func (c *Client) sendMediaAction(ctx context.Context, action *Action, caption, parseMode string, callbackSaver CallbackSaver) (sentMessage, error) {
+	chatID := action.User.TgID
+
+	media, err := buildActionMedia(chatID, action.Content.Attachment, caption, parseMode)
+	if err != nil {
+		return sentMessage{}, err
+	}
+	if media.config == nil {
 		msg := tgbotapi.NewMessage(chatID, caption)
 		msg.ParseMode = parseMode
-		baseChat = msg.BaseChat
-		if err := c.applyReplyMarkup(ctx, action, &msg.BaseChat, callbackSaver); err != nil {
-			return tgbotapi.Message{}, err
-		}
-		sent, err = c.bot.Send(msg)
+		media = actionMedia{config: &msg, base: &msg.BaseChat}
 	}
 
-	_ = baseChat // suppress unused variable warning
-	return sent, err
+	if err := c.applyReplyMarkup(ctx, action, media.base, callbackSaver); err != nil {
+		return sentMessage{}, err
+	}
+	opts := actionSendOptions(action)
+	applyBaseOptions(media.base, opts)
+	return c.send(ctx, media.config, opts)
+}
+
+// actionSendOptions picks send options of every action path from Content.Spices
+func actionSendOptions(action *Action) map[string]interface{} {
+	opts := make(map[string]interface{}, 2)
+	for _, key := range []string{"disable_notification", "protect_content"} {
+		if v, ok := action.Content.Spices[key].(bool); ok {
+			opts[key] = v
+		}
+	}
+	return opts
 }
 
 // applyReplyMarkup applies keyboard markup to the message
@@ -367,7 +683,7 @@ func (c *Client) applyReplyMarkup(ctx context.Context, action *Action, baseChat
 	}
 
 	// Generate keyboard from buttons
-	if len(action.Content.Buts) == 0 {
+	if len(action.Content.Buts) == 0 && len(action.Content.ReplyButtons) == 0 {
 		return nil
 	}
 
@@ -378,6 +694,9 @@ func (c *Client) applyReplyMarkup(ctx context.Context, action *Action, baseChat
 
 	switch action.Content.Type {
 	case "inline_keyboard":
+		if len(action.Content.Buts) == 0 {
+			return nil
+		}
 		markup, err := c.buildInlineKeyboardMarkup(ctx, action, colNum, callbackSaver)
 		if err != nil {
 			return err
@@ -390,7 +709,7 @@ func (c *Client) applyReplyMarkup(ctx context.Context, action *Action, baseChat
 	return nil
 }
 
-// convertReplyMarkup converts custom reply_markup to tgbotapi format
+// convertReplyMarkup converts custom reply_markup to the format sent to Telegram
 func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbackSaver CallbackSaver) (interface{}, error) {
 	// Check for inline_keyboard in reply_markup
 	if inlineKeyboard, ok := action.Content.ReplyMarkup["inline_keyboard"]; ok {
@@ -399,7 +718,7 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 			return action.Content.ReplyMarkup, nil
 		}
 
-		var keyboard [][]tgbotapi.InlineKeyboardButton
+		var keyboard [][]InlineKeyboardButton
 		var callbackQueries []*CallbackData
 		index := 0
 
@@ -409,23 +728,32 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 				continue
 			}
 
-			var keyboardRow []tgbotapi.InlineKeyboardButton
+			var keyboardRow []InlineKeyboardButton
 			for _, item := range rowItems {
 				btn, ok := item.(map[string]interface{})
 				if !ok {
 					continue
 				}
 
-				text, _ := btn["text"].(string)
-				button := tgbotapi.InlineKeyboardButton{Text: text}
+				raw, err := json.Marshal(btn)
+				if err != nil {
+					return nil, fmt.Errorf("%w: invalid inline button: %v", ErrInvalidActionPayload, err)
+				}
+				var button InlineKeyboardButton
+				if err := json.Unmarshal(raw, &button); err != nil {
+					return nil, fmt.Errorf("%w: invalid inline button: %v", ErrInvalidActionPayload, err)
+				}
 
-				// Check for URL button
-				if url, ok := btn["url"].(string); ok {
-					button.URL = &url
-				} else {
+				if button.WebApp != nil {
+					if err := validateWebAppURL(button.WebApp.URL); err != nil {
+						return nil, err
+					}
+				}
+				// Buttons of other kinds are kept as is, callback buttons get new callback data
+				if !button.hasAction() {
 					// Generate callback data
 					hash := GenerateCallbackHash(index)
-					button.CallbackData = &hash
+					button.CallbackData = hash
 
 					// Prepare callback data for saving
 					data := &CallbackData{
@@ -452,7 +780,8 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 			}
 		}
 
-		return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: keyboard}, nil
+		// Our markup type is used since tgbotapi buttons have no web_app field
+		return InlineKeyboardMarkup{InlineKeyboard: keyboard}, nil
 	}
 
 	// Check for regular keyboard
@@ -475,8 +804,7 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 				case string:
 					keyboardRow = append(keyboardRow, tgbotapi.NewKeyboardButton(v))
 				case map[string]interface{}:
-					text, _ := v["text"].(string)
-					keyboardRow = append(keyboardRow, tgbotapi.NewKeyboardButton(text))
+					keyboardRow = append(keyboardRow, convertKeyboardButtonMap(v))
 				}
 			}
 			replyKeyboard = append(replyKeyboard, keyboardRow)
@@ -489,6 +817,12 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 		if oneTime, ok := action.Content.ReplyMarkup["one_time_keyboard"].(bool); ok {
 			markup.OneTimeKeyboard = oneTime
 		}
+		if selective, ok := action.Content.ReplyMarkup["selective"].(bool); ok {
+			markup.Selective = selective
+		}
+		if placeholder, ok := action.Content.ReplyMarkup["input_field_placeholder"].(string); ok {
+			markup.InputFieldPlaceholder = placeholder
+		}
 
 		return markup, nil
 	}
@@ -497,68 +831,157 @@ func (c *Client) convertReplyMarkup(ctx context.Context, action *Action, callbac
 }
 
 // buildInlineKeyboardMarkup builds inline keyboard from buttons
-func (c *Client) buildInlineKeyboardMarkup(ctx context.Context, action *Action, colNum int, callbackSaver CallbackSaver) (tgbotapi.InlineKeyboardMarkup, error) {
-	// Generate callback data hashes
-	callbackData := make([]string, len(action.Content.Buts))
+// A button whose action is {"web_app": {"url": "https://..."}} opens a Web App
+// instead of sending a callback query
+func (c *Client) buildInlineKeyboardMarkup(ctx context.Context, action *Action, colNum int, callbackSaver CallbackSaver) (InlineKeyboardMarkup, error) {
+	buttons := make([]InlineKeyboardButton, len(action.Content.Buts))
 	var callbackQueries []*CallbackData
 
-	for i := range action.Content.Buts {
+	for i, text := range action.Content.Buts {
+		var raw json.RawMessage
+		if action.Content.Actions != nil && i < len(action.Content.Actions) {
+			raw = action.Content.Actions[i]
+		}
+
+		webApp, err := webAppOfAction(raw)
+		if err != nil {
+			return InlineKeyboardMarkup{}, err
+		}
+		if webApp != nil {
+			buttons[i] = InlineKeyboardButton{Text: text, WebApp: webApp}
+			continue
+		}
+
+		// Generate callback data hash
 		hash := GenerateCallbackHash(i)
-		callbackData[i] = hash
+		buttons[i] = InlineKeyboardButton{Text: text, CallbackData: hash}
 
-		data := &CallbackData{
+		callbackQueries = append(callbackQueries, &CallbackData{
 			Project:   action.Project,
 			UserID:    action.User.ID,
 			QueryData: hash,
-		}
-		if action.Content.Actions != nil && i < len(action.Content.Actions) {
-			data.Action = action.Content.Actions[i]
-		}
-		callbackQueries = append(callbackQueries, data)
+			Action:    raw,
+		})
 	}
 
 	// Save callback data
 	if callbackSaver != nil && len(callbackQueries) > 0 {
 		if err := callbackSaver.SaveCallbackDataBatch(ctx, callbackQueries); err != nil {
-			return tgbotapi.InlineKeyboardMarkup{}, err
+			return InlineKeyboardMarkup{}, err
 		}
 	}
 
 	// Build keyboard
-	rowCount := int(math.Ceil(float64(len(action.Content.Buts)) / float64(colNum)))
-	keyboard := make([][]tgbotapi.InlineKeyboardButton, 0, rowCount)
+	rowCount := int(math.Ceil(float64(len(buttons)) / float64(colNum)))
+	keyboard := make([][]InlineKeyboardButton, 0, rowCount)
 
-	for i := 0; i < len(action.Content.Buts); i += colNum {
-		var row []tgbotapi.InlineKeyboardButton
-		for j := 0; j < colNum && (i+j) < len(action.Content.Buts); j++ {
-			idx := i + j
-			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
-				action.Content.Buts[idx],
-				callbackData[idx],
-			))
+	for i := 0; i < len(buttons); i += colNum {
+		end := i + colNum
+		if end > len(buttons) {
+			end = len(buttons)
 		}
-		keyboard = append(keyboard, row)
+		keyboard = append(keyboard, buttons[i:end])
 	}
 
-	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: keyboard}, nil
+	return InlineKeyboardMarkup{InlineKeyboard: keyboard}, nil
+}
+
+// webAppOfAction returns the Web App of a button action, or nil for callback actions
+func webAppOfAction(raw json.RawMessage) (*WebAppInfo, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var action struct {
+		WebApp *WebAppInfo `json:"web_app"`
+	}
+	// Callback actions may be any JSON, only objects with web_app are Web App buttons
+	if err := json.Unmarshal(raw, &action); err != nil || action.WebApp == nil {
+		return nil, nil
+	}
+	if err := validateWebAppURL(action.WebApp.URL); err != nil {
+		return nil, err
+	}
+	return action.WebApp, nil
+}
+
+// validateWebAppURL checks that a Web App URL is an absolute https URL
+func validateWebAppURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidWebAppURL, rawURL)
+	}
+	return nil
 }
 
-// buildReplyKeyboardMarkup builds reply keyboard from buttons
+// buildReplyKeyboardMarkup builds reply keyboard from ReplyButtons or, if they are empty, from Buts
 func (c *Client) buildReplyKeyboardMarkup(action *Action, colNum int) tgbotapi.ReplyKeyboardMarkup {
-	rowCount := int(math.Ceil(float64(len(action.Content.Buts)) / float64(colNum)))
+	buttons := make([]tgbotapi.KeyboardButton, 0, len(action.Content.Buts))
+	if len(action.Content.ReplyButtons) > 0 {
+		for _, button := range action.Content.ReplyButtons {
+			buttons = append(buttons, convertKeyboardButton(button))
+		}
+	} else {
+		for _, text := range action.Content.Buts {
+			buttons = append(buttons, tgbotapi.NewKeyboardButton(text))
+		}
+	}
+
+	rowCount := int(math.Ceil(float64(len(buttons)) / float64(colNum)))
 	keyboard := make([][]tgbotapi.KeyboardButton, 0, rowCount)
 
-	for i := 0; i < len(action.Content.Buts); i += colNum {
-		var row []tgbotapi.KeyboardButton
-		for j := 0; j < colNum && (i+j) < len(action.Content.Buts); j++ {
-			row = append(row, tgbotapi.NewKeyboardButton(action.Content.Buts[i+j]))
+	for i := 0; i < len(buttons); i += colNum {
+		end := i + colNum
+		if end > len(buttons) {
+			end = len(buttons)
 		}
-		keyboard = append(keyboard, row)
+		keyboard = append(keyboard, buttons[i:end])
 	}
 
-	return tgbotapi.ReplyKeyboardMarkup{
+	markup := tgbotapi.ReplyKeyboardMarkup{
 		Keyboard:        keyboard,
 		ResizeKeyboard:  true,
 		OneTimeKeyboard: true,
 	}
+	if opts := action.Content.Keyboard; opts != nil {
+		if opts.ResizeKeyboard != nil {
+			markup.ResizeKeyboard = *opts.ResizeKeyboard
+		}
+		if opts.OneTimeKeyboard != nil {
+			markup.OneTimeKeyboard = *opts.OneTimeKeyboard
+		}
+		markup.Selective = opts.Selective
+		markup.InputFieldPlaceholder = opts.InputFieldPlaceholder
+	}
+	return markup
+}
+
+// convertKeyboardButton converts KeyboardButton to tgbotapi format
+func convertKeyboardButton(button KeyboardButton) tgbotapi.KeyboardButton {
+	tgButton := tgbotapi.KeyboardButton{
+		Text:            button.Text,
+		RequestContact:  button.RequestContact,
+		RequestLocation: button.RequestLocation,
+	}
+	if button.RequestPoll != nil {
+		tgButton.RequestPoll = &tgbotapi.KeyboardButtonPollType{Type: button.RequestPoll.Type}
+	}
+	return tgButton
+}
+
+// convertKeyboardButtonMap converts a reply keyboard button from custom reply_markup
+func convertKeyboardButtonMap(btn map[string]interface{}) tgbotapi.KeyboardButton {
+	text, _ := btn["text"].(string)
+	button := tgbotapi.NewKeyboardButton(text)
+	if requestContact, ok := btn["request_contact"].(bool); ok {
+		button.RequestContact = requestContact
+	}
+	if requestLocation, ok := btn["request_location"].(bool); ok {
+		button.RequestLocation = requestLocation
+	}
+	if requestPoll, ok := btn["request_poll"].(map[string]interface{}); ok {
+		pollType, _ := requestPoll["type"].(string)
+		button.RequestPoll = &tgbotapi.KeyboardButtonPollType{Type: pollType}
+	}
+	return button
 }
@@ -0,0 +1,244 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExecuteActionContentTypeRouting(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    Content
+		wantMethod string
+		wantErr    error
+	}{
+		{
+			name:       "media type with attachment",
+			content:    Content{Type: "photo", Attachment: &Attachment{Type: "photo", URL: "https://example.com/a.jpg"}},
+			wantMethod: "sendPhoto",
+		},
+		{
+			name:       "unknown type with attachment",
+			content:    Content{Type: "legacy", Attachment: &Attachment{Type: "document", URL: "file_id"}},
+			wantMethod: "sendDocument",
+		},
+		{
+			name:    "unknown type without attachment",
+			content: Content{Type: "legacy", Text: "hi"},
+			wantErr: ErrUnsupportedContentType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method string
+			client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = TestMethod(r)
+				fmt.Fprint(w, `{"ok":true,"result":{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}}}`)
+			}))
+
+			action := &Action{User: ActionUser{TgID: 10}, Content: tt.content}
+			result, err := client.ExecuteAction(context.Background(), action, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExecuteAction() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecuteAction() error = %v", err)
+			}
+			if method != tt.wantMethod || result.MessageID != 7 {
+				t.Errorf("sent with %s, message %d, want %s, message 7", method, result.MessageID, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestExecuteActionMediaGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		wantIDs []int64
+		wantErr bool
+	}{
+		{
+			name:    "all messages",
+			result:  `[{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}},{"message_id":8,"date":1,"chat":{"id":10,"type":"private"}}]`,
+			wantIDs: []int64{7, 8},
+		},
+		{
+			name:    "empty response",
+			result:  `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"ok":true,"result":%s}`, tt.result)
+			}))
+
+			action := &Action{User: ActionUser{TgID: 10}, Content: Content{
+				Type: "media_group",
+				Attachments: []Attachment{
+					{Type: "photo", URL: "https://example.com/a.jpg"},
+					{Type: "video", URL: "https://example.com/b.mp4"},
+				},
+			}}
+			result, err := client.ExecuteAction(context.Background(), action, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ExecuteAction() succeeded on an empty response")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecuteAction() error = %v", err)
+			}
+			if result.MessageID != tt.wantIDs[0] || !reflect.DeepEqual(result.MessageIDs, tt.wantIDs) {
+				t.Errorf("MessageID = %d, MessageIDs = %v, want %v", result.MessageID, result.MessageIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestExecuteActionFitsCaption(t *testing.T) {
+	long := strings.Repeat("a", MaxCaptionLength+10)
+	tests := []struct {
+		name    string
+		content Content
+		result  string
+		form    string
+	}{
+		{
+			name:    "single media",
+			content: Content{Type: "photo", Text: long, Attachment: &Attachment{Type: "photo", URL: "https://example.com/a.jpg"}},
+			result:  `{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}}`,
+			form:    "caption",
+		},
+		{
+			name: "media group",
+			content: Content{Type: "media_group", Text: long, Attachments: []Attachment{
+				{Type: "photo", URL: "https://example.com/a.jpg"},
+				{Type: "photo", URL: "https://example.com/b.jpg"},
+			}},
+			result: `[{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}}]`,
+			form:   "media",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.FormValue(tt.form)
+				fmt.Fprintf(w, `{"ok":true,"result":%s}`, tt.result)
+			}))
+
+			action := &Action{User: ActionUser{TgID: 10}, Content: tt.content}
+			if _, err := client.ExecuteAction(context.Background(), action, nil); err != nil {
+				t.Fatalf("ExecuteAction() error = %v", err)
+			}
+
+			truncated := long[:MaxCaptionLength-3] + "..."
+			if !strings.Contains(got, truncated) || strings.Contains(got, long) {
+				t.Errorf("%s was not truncated to MaxCaptionLength", tt.form)
+			}
+		})
+	}
+
+	t.Run("formatted caption too long", func(t *testing.T) {
+		client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("caption over the limit was sent")
+		}))
+		action := &Action{User: ActionUser{TgID: 10}, Content: Content{
+			Type:       "photo",
+			Text:       "*" + long + "*",
+			Spices:     map[string]interface{}{"parse_mode": ParseModeMarkdownV2},
+			Attachment: &Attachment{Type: "photo", URL: "https://example.com/a.jpg"},
+		}}
+		if _, err := client.ExecuteAction(context.Background(), action, nil); !errors.Is(err, ErrCaptionTooLong) {
+			t.Errorf("ExecuteAction() error = %v, want ErrCaptionTooLong", err)
+		}
+	})
+}
+
+func TestMessageToActionQuizRoundTrip(t *testing.T) {
+	msg := &Message{Poll: &Poll{
+		Question:        "2+2?",
+		Options:         []PollOption{{Text: "4"}, {Text: "5"}},
+		Type:            "quiz",
+		CorrectOptionID: 0,
+		Explanation:     "basic math",
+	}}
+	action, err := MessageToAction(msg, 10)
+	if err != nil {
+		t.Fatalf("MessageToAction() error = %v", err)
+	}
+
+	var form url.Values
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.Form
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}))
+	if _, err := client.ExecuteAction(context.Background(), action, nil); err != nil {
+		t.Fatalf("ExecuteAction() error = %v", err)
+	}
+
+	for key, want := range map[string]string{"type": "quiz", "correct_option_id": "0", "explanation": "basic math", "allows_multiple_answers": "false"} {
+		if got := form.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestConvertReplyMarkupKeepsButtonKinds(t *testing.T) {
+	var markup string
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markup = r.FormValue("reply_markup")
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":7,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}))
+
+	action := &Action{User: ActionUser{TgID: 10}, Content: Content{Text: "hi", ReplyMarkup: map[string]interface{}{
+		"inline_keyboard": []interface{}{[]interface{}{
+			map[string]interface{}{"text": "share", "switch_inline_query": ""},
+			map[string]interface{}{"text": "here", "switch_inline_query_current_chat": "q"},
+			map[string]interface{}{"text": "pick", "switch_inline_query_chosen_chat": map[string]interface{}{"query": "q", "allow_user_chats": true}},
+			map[string]interface{}{"text": "copy", "copy_text": map[string]interface{}{"text": "code"}},
+			map[string]interface{}{"text": "login", "login_url": map[string]interface{}{"url": "https://x.com/login"}},
+			map[string]interface{}{"text": "pay", "pay": true},
+			map[string]interface{}{"text": "play", "callback_game": map[string]interface{}{}},
+			map[string]interface{}{"text": "press", "callback_data": "old"},
+		}},
+	}}}
+	if _, err := client.ExecuteAction(context.Background(), action, nil); err != nil {
+		t.Fatalf("ExecuteAction() error = %v", err)
+	}
+
+	var got InlineKeyboardMarkup
+	if err := json.Unmarshal([]byte(markup), &got); err != nil {
+		t.Fatalf("failed to decode reply_markup %q: %v", markup, err)
+	}
+	row := got.InlineKeyboard[0]
+	if len(row) != 8 {
+		t.Fatalf("got %d buttons, want 8", len(row))
+	}
+	for _, button := range row[:7] {
+		if button.CallbackData != "" || !button.hasAction() {
+			t.Errorf("button %q = %+v, want its own kind kept", button.Text, button)
+		}
+	}
+	if row[0].SwitchInlineQuery == nil || *row[0].SwitchInlineQuery != "" {
+		t.Errorf("empty switch_inline_query was lost")
+	}
+	if row[7].CallbackData == "" || row[7].CallbackData == "old" {
+		t.Errorf("callback button got callback_data %q, want a new one", row[7].CallbackData)
+	}
+}
@@ -0,0 +1,94 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// defaultBroadcastRate is the Telegram limit for messages to different users per second
+const defaultBroadcastRate = 30
+
+// WithBroadcastRate sets how many messages per second Broadcast sends
+func WithBroadcastRate(perSecond int) Option {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.broadcastRate = perSecond
+		}
+	}
+}
+
+// BroadcastResult is the outcome of sending a broadcast message to one chat
+type BroadcastResult struct {
+	ChatID    int64
+	MessageID int64
+	Err       error
+}
+
+// Broadcast sends the same message to every chat, paced by the broadcast rate
+// Each recipient gets a BroadcastResult, errors such as blocked users don't stop
+// the broadcast. A rate limited send is retried once after retry_after.
+// The channel is closed when all chats are processed or ctx is cancelled
+func (c *Client) Broadcast(ctx context.Context, chatIDs []int64, text string, opts map[string]interface{}) (<-chan BroadcastResult, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(chan BroadcastResult)
+	go func() {
+		defer close(results)
+
+		ticker := time.NewTicker(time.Second / time.Duration(c.broadcastRate))
+		defer ticker.Stop()
+
+		for _, chatID := range chatIDs {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			result := c.broadcastTo(ctx, chatID, text, opts)
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// broadcastTo sends a broadcast message to one chat, retrying once on rate limit
+func (c *Client) broadcastTo(ctx context.Context, chatID int64, text string, opts map[string]interface{}) BroadcastResult {
+	msg, err := c.SendMessage(ctx, chatID, text, opts)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == 429 {
+		wait := time.Duration(apiErr.RetryAfter) * time.Second
+		if wait <= 0 {
+			wait = time.Second
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return BroadcastResult{ChatID: chatID, Err: ctx.Err()}
+		case <-timer.C:
+		}
+		msg, err = c.SendMessage(ctx, chatID, text, opts)
+	}
+
+	result := BroadcastResult{ChatID: chatID, Err: err}
+	if msg != nil {
+		result.MessageID = msg.MessageID
+	}
+	return result
+}
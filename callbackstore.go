@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultCallbackStoreSize is the default number of callback entries kept by MemoryCallbackStore
+const defaultCallbackStoreSize = 100000
+
+// callbackEntry is a stored callback data with its expiry
+type callbackEntry struct {
+	data      *CallbackData
+	expiresAt time.Time
+}
+
+// MemoryCallbackStore is an in-memory CallbackSaver and CallbackResolver with TTL
+// The store keeps at most 100000 entries by default, the least recently used
+// entries are evicted first. Expired entries are removed by a background goroutine,
+// call Close to stop it
+type MemoryCallbackStore struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // Front is the most recently used entry
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMemoryCallbackStore creates an in-memory callback store, 0 ttl keeps entries until evicted
+func NewMemoryCallbackStore(ttl time.Duration) *MemoryCallbackStore {
+	s := &MemoryCallbackStore{
+		ttl:        ttl,
+		maxEntries: defaultCallbackStoreSize,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		stop:       make(chan struct{}),
+	}
+	if ttl > 0 {
+		go s.janitor()
+	}
+	return s
+}
+
+// SetMaxEntries changes the size limit, 0 or less removes it
+func (s *MemoryCallbackStore) SetMaxEntries(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxEntries = n
+	s.evict()
+}
+
+// SaveCallbackData stores callback data
+func (s *MemoryCallbackStore) SaveCallbackData(ctx context.Context, data *CallbackData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(data, time.Now())
+	s.evict()
+	return nil
+}
+
+// SaveCallbackDataBatch stores callback data of several buttons
+func (s *MemoryCallbackStore) SaveCallbackDataBatch(ctx context.Context, data []*CallbackData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, d := range data {
+		s.put(d, now)
+	}
+	s.evict()
+	return nil
+}
+
+// ResolveCallbackData returns stored callback data or ErrCallbackDataNotFound
+func (s *MemoryCallbackStore) ResolveCallbackData(ctx context.Context, queryData string) (*CallbackData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[queryData]
+	if !ok {
+		return nil, ErrCallbackDataNotFound
+	}
+
+	entry := elem.Value.(*callbackEntry)
+	if s.expired(entry, time.Now()) {
+		s.remove(elem)
+		return nil, ErrCallbackDataNotFound
+	}
+
+	s.lru.MoveToFront(elem)
+	return entry.data, nil
+}
+
+// Len returns the number of stored entries, including expired ones not removed yet
+func (s *MemoryCallbackStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+// Close stops the background goroutine removing expired entries
+func (s *MemoryCallbackStore) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// put adds or replaces an entry, must be called with mu held
+func (s *MemoryCallbackStore) put(data *CallbackData, now time.Time) {
+	if data == nil {
+		return
+	}
+
+	entry := &callbackEntry{data: data}
+	if s.ttl > 0 {
+		entry.expiresAt = now.Add(s.ttl)
+	}
+
+	if elem, ok := s.entries[data.QueryData]; ok {
+		elem.Value = entry
+		s.lru.MoveToFront(elem)
+		return
+	}
+	s.entries[data.QueryData] = s.lru.PushFront(entry)
+}
+
+// evict drops least recently used entries over the size limit, must be called with mu held
+func (s *MemoryCallbackStore) evict() {
+	if s.maxEntries <= 0 {
+		return
+	}
+	for s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+}
+
+// remove deletes an entry, must be called with mu held
+func (s *MemoryCallbackStore) remove(elem *list.Element) {
+	entry := s.lru.Remove(elem).(*callbackEntry)
+	delete(s.entries, entry.data.QueryData)
+}
+
+// expired reports whether the entry is expired at the given moment
+func (s *MemoryCallbackStore) expired(entry *callbackEntry, now time.Time) bool {
+	return !entry.expiresAt.IsZero() && now.After(entry.expiresAt)
+}
+
+// janitor removes expired entries once per TTL period until Close is called
+func (s *MemoryCallbackStore) janitor() {
+	ticker := time.NewTicker(s.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.sweep(now)
+		}
+	}
+}
+
+// sweep removes all entries expired at the given moment
+func (s *MemoryCallbackStore) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, elem := range s.entries {
+		if s.expired(elem.Value.(*callbackEntry), now) {
+			s.lru.Remove(elem)
+			delete(s.entries, key)
+		}
+	}
+}
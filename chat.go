@@ -0,0 +1,360 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Length limits of chat title and description in UTF-16 code units
+const (
+	maxChatTitleLength       = 128
+	maxChatDescriptionLength = 255
+)
+
+// BanChatMember bans a user in a group, supergroup or channel
+// untilDate is a unix time when the user will be unbanned, 0 bans forever
+func (c *Client) BanChatMember(ctx context.Context, chatID, userID int64, untilDate int64, revokeMessages bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.BanChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: chatID, UserID: userID},
+		UntilDate:        untilDate,
+		RevokeMessages:   revokeMessages,
+	})
+	return c.wrapError(err)
+}
+
+// UnbanChatMember unbans a previously banned user
+// With onlyIfBanned the user is not kicked from the chat if they are a member
+func (c *Client) UnbanChatMember(ctx context.Context, chatID, userID int64, onlyIfBanned bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.UnbanChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: chatID, UserID: userID},
+		OnlyIfBanned:     onlyIfBanned,
+	})
+	return c.wrapError(err)
+}
+
+// ApproveChatJoinRequest approves a request of the user to join the chat
+func (c *Client) ApproveChatJoinRequest(ctx context.Context, chatID, userID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.ApproveChatJoinRequestConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		UserID:     userID,
+	})
+	return c.wrapError(err)
+}
+
+// DeclineChatJoinRequest declines a request of the user to join the chat
+func (c *Client) DeclineChatJoinRequest(ctx context.Context, chatID, userID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.DeclineChatJoinRequest{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		UserID:     userID,
+	})
+	return c.wrapError(err)
+}
+
+// RestrictChatMember restricts a user in a supergroup
+// untilDate is a unix time when restrictions will be lifted, 0 restricts forever.
+// Without useIndependentPermissions Telegram derives some permissions from others,
+// e.g. CanSendPolls implies CanSendMessages
+func (c *Client) RestrictChatMember(ctx context.Context, chatID, userID int64, permissions ChatPermissions, useIndependentPermissions bool, untilDate int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero64("user_id", userID)
+	params.AddNonZero64("until_date", untilDate)
+	if err := addPermissionParams(params, permissions, useIndependentPermissions); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).MakeRequest("restrictChatMember", params)
+	return c.wrapError(err)
+}
+
+// SetChatPermissions sets default permissions of all non-administrator members of a group or supergroup
+// Pass zero ChatPermissions to make the chat read-only. See RestrictChatMember for useIndependentPermissions
+func (c *Client) SetChatPermissions(ctx context.Context, chatID int64, perms ChatPermissions, useIndependentPermissions bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	if err := addPermissionParams(params, perms, useIndependentPermissions); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).MakeRequest("setChatPermissions", params)
+	return c.wrapError(err)
+}
+
+// PromoteChatMember promotes or demotes a user in a supergroup or channel
+// Pass zero ChatAdministratorRights to demote the user
+func (c *Client) PromoteChatMember(ctx context.Context, chatID, userID int64, rights ChatAdministratorRights) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.PromoteChatMemberConfig{
+		ChatMemberConfig:    tgbotapi.ChatMemberConfig{ChatID: chatID, UserID: userID},
+		IsAnonymous:         rights.IsAnonymous,
+		CanManageChat:       rights.CanManageChat,
+		CanChangeInfo:       rights.CanChangeInfo,
+		CanPostMessages:     rights.CanPostMessages,
+		CanEditMessages:     rights.CanEditMessages,
+		CanDeleteMessages:   rights.CanDeleteMessages,
+		CanManageVoiceChats: rights.CanManageVoiceChats,
+		CanInviteUsers:      rights.CanInviteUsers,
+		CanRestrictMembers:  rights.CanRestrictMembers,
+		CanPinMessages:      rights.CanPinMessages,
+		CanPromoteMembers:   rights.CanPromoteMembers,
+	})
+	return c.wrapError(err)
+}
+
+// PinChatMessage pins a message in a chat
+// If the message doesn't exist the returned error satisfies IsMessageNotFoundError
+func (c *Client) PinChatMessage(ctx context.Context, chatID, messageID int64, disableNotification bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.PinChatMessageConfig{
+		ChatID:              chatID,
+		MessageID:           int(messageID),
+		DisableNotification: disableNotification,
+	})
+	return c.wrapError(err)
+}
+
+// UnpinChatMessage unpins a message in a chat, 0 messageID unpins the most recent pinned message
+func (c *Client) UnpinChatMessage(ctx context.Context, chatID, messageID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.UnpinChatMessageConfig{
+		ChatID:    chatID,
+		MessageID: int(messageID),
+	})
+	return c.wrapError(err)
+}
+
+// UnpinAllChatMessages unpins all pinned messages in a chat
+func (c *Client) UnpinAllChatMessages(ctx context.Context, chatID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.UnpinAllChatMessagesConfig{
+		ChatID: chatID,
+	})
+	return c.wrapError(err)
+}
+
+// SetChatPhoto sets a new profile photo of a group, supergroup or channel
+// The photo can't be a file_id or URL, it has to be uploaded
+func (c *Client) SetChatPhoto(ctx context.Context, chatID int64, photo FileSource) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.SetChatPhotoConfig{
+		BaseFile: tgbotapi.BaseFile{
+			BaseChat: tgbotapi.BaseChat{ChatID: chatID},
+			File:     photo.requestFileData(),
+		},
+	})
+	return c.wrapError(err)
+}
+
+// DeleteChatPhoto deletes the profile photo of a group, supergroup or channel
+func (c *Client) DeleteChatPhoto(ctx context.Context, chatID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.NewDeleteChatPhoto(chatID))
+	return c.wrapError(err)
+}
+
+// SetChatTitle changes the title of a group, supergroup or channel, 1-128 characters
+func (c *Client) SetChatTitle(ctx context.Context, chatID int64, title string) error {
+	if n := utf16Len(title); n < 1 || n > maxChatTitleLength {
+		return fmt.Errorf("chat title must be 1-%d characters, got %d", maxChatTitleLength, n)
+	}
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.NewChatTitle(chatID, title))
+	return c.wrapError(err)
+}
+
+// SetChatDescription changes the description of a group, supergroup or channel
+// Description is up to 255 characters, empty description removes it
+func (c *Client) SetChatDescription(ctx context.Context, chatID int64, description string) error {
+	if n := utf16Len(description); n > maxChatDescriptionLength {
+		return fmt.Errorf("chat description must be at most %d characters, got %d", maxChatDescriptionLength, n)
+	}
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.NewChatDescription(chatID, description))
+	return c.wrapError(err)
+}
+
+// GetChat returns full information about a chat
+func (c *Client) GetChat(ctx context.Context, chatID int64) (*ChatFullInfo, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.botFor(ctx).Request(tgbotapi.ChatInfoConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+	})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	var chat tgbotapi.Chat
+	if err := json.Unmarshal(resp.Result, &chat); err != nil {
+		return nil, fmt.Errorf("failed to decode chat: %w", err)
+	}
+	var fields rawChatFields
+	if err := json.Unmarshal(resp.Result, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode chat: %w", err)
+	}
+
+	result := &ChatFullInfo{
+		Chat: Chat{
+			ID:        chat.ID,
+			Type:      chat.Type,
+			Title:     chat.Title,
+			Username:  chat.UserName,
+			FirstName: chat.FirstName,
+			LastName:  chat.LastName,
+		},
+		Bio:                   chat.Bio,
+		Description:           chat.Description,
+		InviteLink:            chat.InviteLink,
+		SlowModeDelay:         chat.SlowModeDelay,
+		MessageAutoDeleteTime: chat.MessageAutoDeleteTime,
+		HasProtectedContent:   chat.HasProtectedContent,
+		StickerSetName:        chat.StickerSetName,
+		LinkedChatID:          chat.LinkedChatID,
+	}
+
+	if chat.Photo != nil {
+		result.Photo = &ChatPhoto{
+			SmallFileID:       chat.Photo.SmallFileID,
+			SmallFileUniqueID: chat.Photo.SmallFileUniqueID,
+			BigFileID:         chat.Photo.BigFileID,
+			BigFileUniqueID:   chat.Photo.BigFileUniqueID,
+		}
+	}
+
+	// tgbotapi knows only the legacy permissions
+	result.Permissions = fields.Permissions
+	if fields.PinnedMessage != nil {
+		result.PinnedMessage = convertSentMessage(fields.PinnedMessage)
+	}
+
+	return result, nil
+}
+
+// GetChatMember returns information about a member of a chat
+func (c *Client) GetChatMember(ctx context.Context, chatID, userID int64) (*ChatMember, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	member, err := c.botFor(ctx).GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return convertChatMember(&member), nil
+}
+
+// GetChatMemberCount returns the number of members in a chat
+func (c *Client) GetChatMemberCount(ctx context.Context, chatID int64) (int, error) {
+	if err := c.initBot(); err != nil {
+		return 0, err
+	}
+
+	count, err := c.botFor(ctx).GetChatMembersCount(tgbotapi.ChatMemberCountConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+	})
+	if err != nil {
+		return 0, c.wrapError(err)
+	}
+
+	return count, nil
+}
+
+// convertChatMember converts tgbotapi.ChatMember to our ChatMember type
+func convertChatMember(m *tgbotapi.ChatMember) *ChatMember {
+	return &ChatMember{
+		Status:                m.Status,
+		User:                  convertUser(m.User),
+		CustomTitle:           m.CustomTitle,
+		IsAnonymous:           m.IsAnonymous,
+		UntilDate:             m.UntilDate,
+		IsMember:              m.IsMember,
+		CanBeEdited:           m.CanBeEdited,
+		CanManageChat:         m.CanManageChat,
+		CanPostMessages:       m.CanPostMessages,
+		CanEditMessages:       m.CanEditMessages,
+		CanDeleteMessages:     m.CanDeleteMessages,
+		CanManageVoiceChats:   m.CanManageVoiceChats,
+		CanRestrictMembers:    m.CanRestrictMembers,
+		CanPromoteMembers:     m.CanPromoteMembers,
+		CanChangeInfo:         m.CanChangeInfo,
+		CanInviteUsers:        m.CanInviteUsers,
+		CanPinMessages:        m.CanPinMessages,
+		CanSendMessages:       m.CanSendMessages,
+		CanSendMediaMessages:  m.CanSendMediaMessages,
+		CanSendPolls:          m.CanSendPolls,
+		CanSendOtherMessages:  m.CanSendOtherMessages,
+		CanAddWebPagePreviews: m.CanAddWebPagePreviews,
+	}
+}
+
+// rawChatFields are getChat fields tgbotapi doesn't decode
+type rawChatFields struct {
+	Permissions   *ChatPermissions `json:"permissions"`
+	PinnedMessage *sentMessage     `json:"pinned_message"`
+}
+
+// addPermissionParams adds permissions and use_independent_chat_permissions to params
+// tgbotapi doesn't support granular media permissions, so requests are made directly
+func addPermissionParams(params tgbotapi.Params, perms ChatPermissions, useIndependent bool) error {
+	if err := params.AddInterface("permissions", perms); err != nil {
+		return err
+	}
+	params.AddBool("use_independent_chat_permissions", useIndependent)
+	return nil
+}
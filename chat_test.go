@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetChatGranularPermissions(t *testing.T) {
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":{"id":-100,"type":"supergroup","permissions":{
+			"can_send_messages":true,"can_send_audios":true,"can_send_documents":true,"can_send_photos":true,
+			"can_send_videos":true,"can_send_video_notes":true,"can_send_voice_notes":true,"can_manage_topics":true}}}`)
+	}))
+
+	chat, err := client.GetChat(context.Background(), -100)
+	if err != nil {
+		t.Fatalf("GetChat() error = %v", err)
+	}
+	want := ChatPermissions{
+		CanSendMessages:   true,
+		CanSendAudios:     true,
+		CanSendDocuments:  true,
+		CanSendPhotos:     true,
+		CanSendVideos:     true,
+		CanSendVideoNotes: true,
+		CanSendVoiceNotes: true,
+		CanManageTopics:   true,
+	}
+	if chat.Permissions == nil || *chat.Permissions != want {
+		t.Errorf("Permissions = %+v, want %+v", chat.Permissions, want)
+	}
+}
+
+func TestSetChatPermissionsIndependentFlag(t *testing.T) {
+	for _, independent := range []bool{false, true} {
+		var got string
+		client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.FormValue("use_independent_chat_permissions")
+			fmt.Fprint(w, `{"ok":true,"result":true}`)
+		}))
+
+		if err := client.SetChatPermissions(context.Background(), -100, ChatPermissions{CanSendMessages: true}, independent); err != nil {
+			t.Fatalf("SetChatPermissions() error = %v", err)
+		}
+		if (got == "true") != independent {
+			t.Errorf("use_independent_chat_permissions = %q, want %v", got, independent)
+		}
+	}
+}
+
+func TestGetChatPinnedMessageRawFields(t *testing.T) {
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":{"id":-100,"type":"supergroup","pinned_message":{
+			"message_id":5,"date":1,"chat":{"id":-100,"type":"supergroup"},"is_from_offline":true,
+			"sticker":{"file_id":"s","file_unique_id":"u","type":"custom_emoji","width":100,"height":100,"is_animated":false,"is_video":false}}}}`)
+	}))
+
+	chat, err := client.GetChat(context.Background(), -100)
+	if err != nil {
+		t.Fatalf("GetChat() error = %v", err)
+	}
+	pinned := chat.PinnedMessage
+	if pinned == nil || pinned.MessageID != 5 {
+		t.Fatalf("PinnedMessage = %+v, want message 5", pinned)
+	}
+	if !pinned.IsFromOffline || pinned.Sticker == nil || pinned.Sticker.Type != "custom_emoji" {
+		t.Errorf("PinnedMessage lost raw fields: IsFromOffline = %v, Sticker = %+v", pinned.IsFromOffline, pinned.Sticker)
+	}
+}
@@ -0,0 +1,78 @@
+package telegram
+
+import (
+	"sync/atomic"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultUnauthorizedThreshold is the number of consecutive 401 responses that trips the circuit
+	defaultUnauthorizedThreshold = 3
+)
+
+// circuitBreaker stops sending after repeated 401 responses
+type circuitBreaker struct {
+	threshold int32
+	failures  int32
+	tripped   int32
+}
+
+// allow reports whether requests may be sent
+func (b *circuitBreaker) allow() bool {
+	return atomic.LoadInt32(&b.tripped) == 0
+}
+
+// record registers a request result and reports whether the circuit has just tripped
+func (b *circuitBreaker) record(err error) bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	tgErr, ok := err.(*tgbotapi.Error)
+	if !ok || tgErr.Code != 401 {
+		if err == nil {
+			atomic.StoreInt32(&b.failures, 0)
+		}
+		return false
+	}
+
+	if atomic.AddInt32(&b.failures, 1) < b.threshold {
+		return false
+	}
+	return atomic.CompareAndSwapInt32(&b.tripped, 0, 1)
+}
+
+// reset closes the circuit
+func (b *circuitBreaker) reset() {
+	atomic.StoreInt32(&b.failures, 0)
+	atomic.StoreInt32(&b.tripped, 0)
+}
+
+// WithUnauthorizedThreshold sets how many consecutive 401 responses trip the circuit breaker
+// After that sends fail with ErrClientUnauthorized until ResetCircuit is called, 0 disables the breaker
+func WithUnauthorizedThreshold(threshold int) Option {
+	return func(c *Client) {
+		c.circuit.threshold = int32(threshold)
+	}
+}
+
+// ResetCircuit resumes sending after the circuit breaker has tripped on 401 responses
+func (c *Client) ResetCircuit() {
+	c.circuit.reset()
+}
+
+// IsCircuitOpen reports whether sends are blocked because of repeated 401 responses
+func (c *Client) IsCircuitOpen() bool {
+	return !c.circuit.allow()
+}
+
+// recordResult feeds a send result to the circuit breaker
+func (c *Client) recordResult(err error) {
+	if c.circuit.record(err) && c.logger != nil {
+		c.logger.Error("telegram circuit breaker tripped, token is unauthorized",
+			zap.Int32("consecutive_401", c.circuit.threshold),
+		)
+	}
+}
@@ -1,36 +1,95 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const (
 	defaultTimeout = 30 * time.Second
+
+	// maxDownloadSize is the bot API limit for downloading files
+	maxDownloadSize = 20 * 1024 * 1024
 )
 
 // Client is a Telegram Bot API client wrapper over tgbotapi
 type Client struct {
+	mu         sync.RWMutex // guards bot and token
 	bot        *tgbotapi.BotAPI
 	token      string
 	httpClient *http.Client
 	logger     *zap.Logger
 	debug      bool
+
+	// HTTP client timeout, applied after all options, see WithTimeout
+	httpTimeout    time.Duration
+	httpTimeoutSet bool
+
+	// Default deadline of every request and context of requests without a caller
+	requestTimeout time.Duration
+	baseCtx        context.Context
+
+	// Include message text in debug logs, see WithLogMessageContent
+	logMessageContent bool
+
+	// Optional token pool used for sending messages
+	poolTokens []string
+	pool       *tokenPool
+	poolRate   rate.Limit // Sends per second of every pool token
+
+	// Breaker for repeated 401 responses
+	circuit circuitBreaker
+
+	// Messages per second sent by Broadcast
+	broadcastRate int
+
+	// Optional hook and metrics around every API request
+	requestHook RequestHook
+	stats       Stats
+
+	// Create bots without validating the token with getMe
+	skipGetMe bool
+
+	// Bot API server endpoints in tgbotapi format, see WithAPIEndpoint
+	apiEndpoint  string
+	fileEndpoint string
+
+	// Default cache_time of callback answers in seconds, see WithCallbackCacheTime
+	callbackCacheTime int
+
+	// Sent message keys of SendMessageOnce, see WithDedupeStore
+	dedupeStore  DedupeStore
+	dedupeWindow time.Duration
+	// Pacing of sends, see WithRateLimiter
+	rateLimiter RateLimiter
 }
 
 // Option is a functional option for Client
 type Option func(*Client)
 
 // WithTimeout sets custom HTTP timeout
+// It applies to the client set by WithHTTPClient regardless of the option order,
+// the passed client is copied and not modified
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
-		c.httpClient.Timeout = timeout
+		c.httpTimeout = timeout
+		c.httpTimeoutSet = true
 	}
 }
 
@@ -41,6 +100,24 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithRequestTimeout sets a default deadline for every Bot API request
+// The deadline is added to the context passed to a method, an earlier deadline
+// of that context still wins. Unlike WithTimeout it also limits time spent in
+// the request hook, e.g. waiting for a rate limiter
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithBaseContext sets the context of requests made without a caller context,
+// such as the getMe request on the first call. context.Background by default
+func WithBaseContext(ctx context.Context) Option {
+	return func(c *Client) {
+		c.baseCtx = ctx
+	}
+}
+
 // WithDebug enables debug mode
 func WithDebug(debug bool) Option {
 	return func(c *Client) {
@@ -48,6 +125,61 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithAPIEndpoint points the client at another Bot API server, e.g. a self-hosted one
+// url is the server base URL like "http://localhost:8081", or a tgbotapi endpoint
+// format like "http://localhost:8081/bot%s/%s". Methods and file downloads use the server
+func WithAPIEndpoint(url string) Option {
+	return func(c *Client) {
+		if strings.Contains(url, "%s") {
+			c.apiEndpoint = url
+			c.fileEndpoint = strings.Replace(url, "/bot%s/", "/file/bot%s/", 1)
+			return
+		}
+		base := strings.TrimRight(url, "/")
+		c.apiEndpoint = base + "/bot%s/%s"
+		c.fileEndpoint = base + "/file/bot%s/%s"
+	}
+}
+
+// WithSkipGetMe creates the bot without the getMe request tgbotapi makes to validate the token
+// The client can be created offline or with a fake token, an invalid token fails
+// on the first real request instead. GetBot().Self has only the ID taken from the token
+func WithSkipGetMe() Option {
+	return func(c *Client) {
+		c.skipGetMe = true
+	}
+}
+
+// WithLogMessageContent includes message text in debug logs
+// Disabled by default since messages may contain personal data,
+// only chat_id and text length are logged then
+func WithLogMessageContent(enabled bool) Option {
+	return func(c *Client) {
+		c.logMessageContent = enabled
+	}
+}
+
+// WithCallbackCacheTime sets how long clients cache callback answers by default
+// Telegram's default is 0, the cache_time option of AnswerCallbackQuery overrides it
+func WithCallbackCacheTime(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.callbackCacheTime = int(d / time.Second)
+		}
+	}
+}
+
+// WithTokenPool enables sending messages through a pool of bot tokens
+// Sends are distributed round-robin across the pool, a token that hits the
+// rate limit is skipped until its retry_after passes and a token that gets 401
+// is disabled. Every token is paced on its own, see WithTokenPoolRate. All other
+// methods (GetMe, webhooks, edits, etc.) use the primary token passed to NewClient
+func WithTokenPool(tokens []string) Option {
+	return func(c *Client) {
+		c.poolTokens = tokens
+	}
+}
+
 // NewClient creates a new Telegram client using tgbotapi
 func NewClient(token string, logger *zap.Logger, opts ...Option) *Client {
 	c := &Client{
@@ -56,28 +188,111 @@ func NewClient(token string, logger *zap.Logger, opts ...Option) *Client {
 			Timeout: defaultTimeout,
 		},
 		logger: logger,
+		circuit: circuitBreaker{
+			threshold: defaultUnauthorizedThreshold,
+		},
+		broadcastRate: defaultBroadcastRate,
+		poolRate:      defaultPoolTokenRate,
+		apiEndpoint:   tgbotapi.APIEndpoint,
+		fileEndpoint:  tgbotapi.FileEndpoint,
+		dedupeStore:   NewMemoryDedupeStore(),
+		dedupeWindow:  defaultDedupeWindow,
+		rateLimiter:   noopRateLimiter{},
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.httpTimeoutSet {
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.httpTimeout
+		c.httpClient = &httpClient
+	}
+
 	return c
 }
 
+// baseContext returns the context of requests made without a caller context
+func (c *Client) baseContext() context.Context {
+	if c.baseCtx != nil {
+		return c.baseCtx
+	}
+	return context.Background()
+}
+
 // initBot lazily initializes the tgbotapi.BotAPI
+// Safe for concurrent use: the bot and the token pool are created exactly once,
+// concurrent first calls wait for the one doing the getMe request. A failed
+// initialization is retried by the next call
 func (c *Client) initBot() error {
+	c.mu.RLock()
+	ready := c.bot != nil
+	c.mu.RUnlock()
+	if ready {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.bot != nil {
 		return nil
 	}
 
-	bot, err := tgbotapi.NewBotAPIWithClient(c.token, tgbotapi.APIEndpoint, c.httpClient)
+	bot, err := c.newBot(c.baseContext(), c.token)
 	if err != nil {
-		return fmt.Errorf("failed to create bot: %w", err)
+		// wrapError can't be used since mu is held
+		return fmt.Errorf("failed to create bot: %w", redactError(err, c.tokensLocked()))
+	}
+
+	bot.Debug = c.debug
+
+	if len(c.poolTokens) > 0 {
+		pool, err := c.newTokenPool(c.poolTokens)
+		if err != nil {
+			return err
+		}
+		c.pool = pool
+	}
+
+	c.bot = bot
+	return nil
+}
+
+// currentBot returns the primary bot instance
+// Requests keep using the instance they started with even if the token is swapped meanwhile
+func (c *Client) currentBot() *tgbotapi.BotAPI {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bot
+}
+
+// SetToken replaces the primary bot token without recreating the Client
+// The new token is validated with getMe before it is used, unless WithSkipGetMe is set.
+// Requests already in flight complete with the old token. The token pool is
+// kept as is. The 401 circuit breaker is reset
+func (c *Client) SetToken(ctx context.Context, newToken string) error {
+	// The pool is built on first init, a bot set here would skip it
+	if err := c.initBot(); err != nil {
+		return err
 	}
 
+	bot, err := c.newBot(ctx, newToken)
+	if err != nil {
+		return fmt.Errorf("failed to validate new token: %w", c.wrapError(err))
+	}
 	bot.Debug = c.debug
+
+	c.mu.Lock()
+	c.token = newToken
 	c.bot = bot
+	c.mu.Unlock()
+
+	c.ResetCircuit()
+
+	if c.logger != nil {
+		c.logger.Info("telegram bot token replaced", zap.String("bot_username", bot.Self.UserName))
+	}
 	return nil
 }
 
@@ -86,7 +301,7 @@ func (c *Client) GetBot() (*tgbotapi.BotAPI, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
-	return c.bot, nil
+	return c.currentBot(), nil
 }
 
 // SendMessage sends a text message to Telegram
@@ -99,6 +314,9 @@ func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, opt
 
 	// Apply options
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 	if disablePreview, ok := opts["disable_web_page_preview"].(bool); ok {
@@ -107,22 +325,37 @@ func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, opt
 	if disableNotification, ok := opts["disable_notification"].(bool); ok {
 		msg.DisableNotification = disableNotification
 	}
-	if replyTo, ok := opts["reply_to_message_id"].(int); ok {
+	if replyTo, ok := asInt(opts["reply_to_message_id"]); ok {
 		msg.ReplyToMessageID = replyTo
 	}
 	if replyMarkup, ok := opts["reply_markup"]; ok {
 		msg.ReplyMarkup = replyMarkup
 	}
+	if entities, ok := opts["entities"].([]MessageEntity); ok {
+		// Entities replace parse_mode, the text is sent as is
+		msg.Entities = convertEntitiesToTg(entities)
+		msg.ParseMode = ""
+	}
 
 	if c.logger != nil {
-		c.logger.Debug("sending message",
+		fields := []zap.Field{
 			zap.Int64("chat_id", chatID),
-			zap.String("text", text),
-		)
+			zap.Int("text_length", utf16Len(text)),
+		}
+		if c.logMessageContent {
+			fields = append(fields, zap.String("text", c.redact(text)))
+		}
+		c.logger.Debug("sending message", fields...)
 	}
 
 	start := time.Now()
-	sent, err := c.bot.Send(msg)
+	var sent sentMessage
+	var err error
+	if replyParams, ok := opts["reply_parameters"].(ReplyParameters); ok {
+		sent, err = c.sendMessageWithReply(ctx, msg, replyParams, opts)
+	} else {
+		sent, err = c.send(ctx, msg, opts)
+	}
 	duration := time.Since(start)
 
 	if c.logger != nil {
@@ -137,95 +370,122 @@ func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, opt
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendPhoto sends a photo
-func (c *Client) SendPhoto(ctx context.Context, chatID int64, photo string, caption string, opts map[string]interface{}) (*Message, error) {
+func (c *Client) SendPhoto(ctx context.Context, chatID int64, photo FileSource, caption string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewPhoto(chatID, tgbotapi.FileURL(photo))
+	msg := tgbotapi.NewPhoto(chatID, photo.requestFileData())
 	msg.Caption = caption
 
-	applyMediaOptions(&msg.BaseChat, &msg.Caption, opts)
+	if err := applyMediaOptions(&msg.BaseChat, &msg.Caption, opts); err != nil {
+		return nil, err
+	}
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendDocument sends a document
-func (c *Client) SendDocument(ctx context.Context, chatID int64, document string, caption string, opts map[string]interface{}) (*Message, error) {
+// The "thumbnail" option takes a FileSource with a JPEG preview up to 320x320,
+// Telegram accepts only uploaded thumbnails
+func (c *Client) SendDocument(ctx context.Context, chatID int64, document FileSource, caption string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewDocument(chatID, tgbotapi.FileURL(document))
+	msg := tgbotapi.NewDocument(chatID, document.requestFileData())
 	msg.Caption = caption
 
-	applyMediaOptions(&msg.BaseChat, &msg.Caption, opts)
+	if err := applyMediaOptions(&msg.BaseChat, &msg.Caption, opts); err != nil {
+		return nil, err
+	}
+	msg.Thumb = thumbnailOption(opts)
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendVideo sends a video
-func (c *Client) SendVideo(ctx context.Context, chatID int64, video string, caption string, opts map[string]interface{}) (*Message, error) {
+// Set "thumbnail" option to attach a custom preview, see SendDocument
+func (c *Client) SendVideo(ctx context.Context, chatID int64, video FileSource, caption string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewVideo(chatID, tgbotapi.FileURL(video))
+	msg := tgbotapi.NewVideo(chatID, video.requestFileData())
 	msg.Caption = caption
 
-	applyMediaOptions(&msg.BaseChat, &msg.Caption, opts)
+	if err := applyMediaOptions(&msg.BaseChat, &msg.Caption, opts); err != nil {
+		return nil, err
+	}
+	msg.Thumb = thumbnailOption(opts)
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendAudio sends an audio file
-func (c *Client) SendAudio(ctx context.Context, chatID int64, audio string, caption string, opts map[string]interface{}) (*Message, error) {
+// Set "thumbnail" option to attach a custom album cover, see SendDocument
+func (c *Client) SendAudio(ctx context.Context, chatID int64, audio FileSource, caption string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewAudio(chatID, tgbotapi.FileURL(audio))
+	msg := tgbotapi.NewAudio(chatID, audio.requestFileData())
 	msg.Caption = caption
 
-	applyMediaOptions(&msg.BaseChat, &msg.Caption, opts)
+	if err := applyMediaOptions(&msg.BaseChat, &msg.Caption, opts); err != nil {
+		return nil, err
+	}
+	msg.Thumb = thumbnailOption(opts)
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendVoice sends a voice message
@@ -237,35 +497,48 @@ func (c *Client) SendVoice(ctx context.Context, chatID int64, voice string, capt
 	msg := tgbotapi.NewVoice(chatID, tgbotapi.FileURL(voice))
 	msg.Caption = caption
 
-	applyMediaOptions(&msg.BaseChat, &msg.Caption, opts)
+	if err := applyMediaOptions(&msg.BaseChat, &msg.Caption, opts); err != nil {
+		return nil, err
+	}
 	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
 		msg.ParseMode = parseMode
 	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
+// defaultVideoNoteLength is the video note diameter used when the length is not known
+const defaultVideoNoteLength = 240
+
 // SendVideoNote sends a video note (round video)
+// The "length" option sets the video diameter in pixels, 240 by default
 func (c *Client) SendVideoNote(ctx context.Context, chatID int64, videoNote string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewVideoNote(chatID, 240, tgbotapi.FileURL(videoNote))
+	length := defaultVideoNoteLength
+	if l, ok := asInt(opts["length"]); ok && l > 0 {
+		length = l
+	}
+	msg := tgbotapi.NewVideoNote(chatID, length, tgbotapi.FileURL(videoNote))
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendSticker sends a sticker
@@ -286,12 +559,12 @@ func (c *Client) SendSticker(ctx context.Context, chatID int64, sticker string,
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendDice sends a dice animation
@@ -305,12 +578,12 @@ func (c *Client) SendDice(ctx context.Context, chatID int64, emoji string, opts
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendContact sends a contact
@@ -332,12 +605,12 @@ func (c *Client) SendContact(ctx context.Context, chatID int64, contact map[stri
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendPoll sends a poll
@@ -369,15 +642,66 @@ func (c *Client) SendPoll(ctx context.Context, chatID int64, poll map[string]int
 	if allowsMultiple, ok := poll["allows_multiple_answers"].(bool); ok {
 		msg.AllowsMultipleAnswers = allowsMultiple
 	}
+	if openPeriod, ok := asInt(poll["open_period"]); ok {
+		msg.OpenPeriod = openPeriod
+	}
+	if closeDate, ok := asInt(poll["close_date"]); ok {
+		msg.CloseDate = closeDate
+	}
+	if explanation, ok := poll["explanation"].(string); ok {
+		msg.Explanation = explanation
+		if parseMode, ok := poll["explanation_parse_mode"].(string); ok {
+			msg.ExplanationParseMode = parseMode
+		} else if parseMode, ok := opts["parse_mode"].(string); ok {
+			msg.ExplanationParseMode = parseMode
+		}
+	}
+
+	// Quiz polls require the correct option
+	correctOptionID, hasCorrectOption := asInt(poll["correct_option_id"])
+	if msg.Type == "quiz" {
+		if !hasCorrectOption {
+			return nil, fmt.Errorf("correct_option_id is required for quiz poll")
+		}
+		if correctOptionID < 0 || correctOptionID >= len(options) {
+			return nil, fmt.Errorf("correct_option_id %d is out of options range [0, %d)", correctOptionID, len(options))
+		}
+	}
+	if hasCorrectOption {
+		msg.CorrectOptionID = int64(correctOptionID)
+	}
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// StopPoll stops a poll sent by the bot and returns its final results
+func (c *Client) StopPoll(ctx context.Context, chatID int64, messageID int64, markup *InlineKeyboardMarkup) (*Poll, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	msg := tgbotapi.NewStopPoll(chatID, int(messageID))
+	var extra url.Values
+	if markup != nil {
+		var err error
+		if extra, err = inlineMarkupParams(*markup); err != nil {
+			return nil, err
+		}
+	}
+
+	poll, err := withExtraParams(c.botFor(ctx), extra).StopPoll(msg)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertPoll(&poll), nil
 }
 
 // SendVenue sends a venue
@@ -402,15 +726,16 @@ func (c *Client) SendVenue(ctx context.Context, chatID int64, venue map[string]i
 
 	applyBaseOptions(&msg.BaseChat, opts)
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
 // SendLocation sends a location
+// Supported options: live_period, horizontal_accuracy, heading, proximity_alert_radius
 func (c *Client) SendLocation(ctx context.Context, chatID int64, latitude, longitude float64, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
@@ -419,217 +744,928 @@ func (c *Client) SendLocation(ctx context.Context, chatID int64, latitude, longi
 	msg := tgbotapi.NewLocation(chatID, latitude, longitude)
 
 	applyBaseOptions(&msg.BaseChat, opts)
+	if livePeriod, ok := asInt(opts["live_period"]); ok {
+		msg.LivePeriod = livePeriod
+	}
+	if accuracy, ok := asFloat(opts["horizontal_accuracy"]); ok {
+		msg.HorizontalAccuracy = accuracy
+	}
+	if heading, ok := asInt(opts["heading"]); ok {
+		msg.Heading = heading
+	}
+	if radius, ok := asInt(opts["proximity_alert_radius"]); ok {
+		msg.ProximityAlertRadius = radius
+	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
-// SendGame sends a game
-func (c *Client) SendGame(ctx context.Context, chatID int64, gameShortName string, opts map[string]interface{}) (*Message, error) {
+// EditMessageLiveLocation moves a live location message to a new point
+// Supported options: horizontal_accuracy, heading, proximity_alert_radius, reply_markup
+func (c *Client) EditMessageLiveLocation(ctx context.Context, chatID, messageID int64, latitude, longitude float64, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.GameConfig{
-		BaseChat:      tgbotapi.BaseChat{ChatID: chatID},
-		GameShortName: gameShortName,
-	}
+	ctx = callContext(ctx, opts)
 
-	applyBaseOptions(&msg.BaseChat, opts)
+	msg := tgbotapi.EditMessageLiveLocationConfig{
+		BaseEdit:  tgbotapi.BaseEdit{ChatID: chatID, MessageID: int(messageID)},
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+	if accuracy, ok := asFloat(opts["horizontal_accuracy"]); ok {
+		msg.HorizontalAccuracy = accuracy
+	}
+	if heading, ok := asInt(opts["heading"]); ok {
+		msg.Heading = heading
+	}
+	if radius, ok := asInt(opts["proximity_alert_radius"]); ok {
+		msg.ProximityAlertRadius = radius
+	}
+	extra, err := inlineMarkupParams(opts["reply_markup"])
+	if err != nil {
+		return nil, err
+	}
 
-	sent, err := c.bot.Send(msg)
+	sent, err := requestMessage(withExtraParams(c.botFor(ctx), extra), msg)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
-// SendChatAction sends a chat action (typing, upload_photo, etc.)
-func (c *Client) SendChatAction(ctx context.Context, chatID int64, action string) error {
+// StopMessageLiveLocation stops updating a live location message
+func (c *Client) StopMessageLiveLocation(ctx context.Context, chatID, messageID int64) (*Message, error) {
 	if err := c.initBot(); err != nil {
-		return err
+		return nil, err
 	}
 
-	msg := tgbotapi.NewChatAction(chatID, action)
-	_, err := c.bot.Request(msg)
-	return c.wrapError(err)
+	msg := tgbotapi.StopMessageLiveLocationConfig{
+		BaseEdit: tgbotapi.BaseEdit{ChatID: chatID, MessageID: int(messageID)},
+	}
+
+	sent, err := requestMessage(c.botFor(ctx), msg)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return convertSentMessage(&sent), nil
 }
 
-// EditMessageText edits text of a message
-func (c *Client) EditMessageText(ctx context.Context, chatID int64, messageID int64, text string, opts map[string]interface{}) (*Message, error) {
+// SendGame sends a game
+func (c *Client) SendGame(ctx context.Context, chatID int64, gameShortName string, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	msg := tgbotapi.NewEditMessageText(chatID, int(messageID), text)
-
-	if parseMode, ok := opts["parse_mode"].(string); ok {
-		msg.ParseMode = parseMode
-	}
-	if disablePreview, ok := opts["disable_web_page_preview"].(bool); ok {
-		msg.DisableWebPagePreview = disablePreview
-	}
-	if replyMarkup, ok := opts["reply_markup"].(tgbotapi.InlineKeyboardMarkup); ok {
-		msg.ReplyMarkup = &replyMarkup
+	msg := tgbotapi.GameConfig{
+		BaseChat:      tgbotapi.BaseChat{ChatID: chatID},
+		GameShortName: gameShortName,
 	}
 
-	sent, err := c.bot.Send(msg)
+	applyBaseOptions(&msg.BaseChat, opts)
+
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return convertMessage(&sent), nil
+	return convertSentMessage(&sent), nil
 }
 
-// DeleteMessage deletes a message
-func (c *Client) DeleteMessage(ctx context.Context, chatID int64, messageID int64) error {
+// SendMediaGroup sends a group of photos, videos, documents or audios as an album
+func (c *Client) SendMediaGroup(ctx context.Context, chatID int64, items []MediaGroupItem, opts map[string]interface{}) ([]*Message, error) {
 	if err := c.initBot(); err != nil {
-		return err
+		return nil, err
 	}
 
-	msg := tgbotapi.NewDeleteMessage(chatID, int(messageID))
-	_, err := c.bot.Request(msg)
-	return c.wrapError(err)
-}
+	ctx = callContext(ctx, opts)
 
-// AnswerCallbackQuery answers a callback query
-func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackQueryID string, opts map[string]interface{}) error {
-	if err := c.initBot(); err != nil {
-		return err
+	media := make([]map[string]interface{}, 0, len(items))
+	var files []tgbotapi.RequestFile
+	for i, item := range items {
+		inputMedia, file, err := item.inputMedia(i)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, inputMedia)
+		if file != nil {
+			files = append(files, *file)
+		}
 	}
 
-	callback := tgbotapi.NewCallback(callbackQueryID, "")
-
-	if text, ok := opts["text"].(string); ok {
-		callback.Text = text
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	if err := params.AddInterface("media", media); err != nil {
+		return nil, err
 	}
-	if showAlert, ok := opts["show_alert"].(bool); ok {
-		callback.ShowAlert = showAlert
+	if disableNotification, ok := opts["disable_notification"].(bool); ok {
+		params.AddBool("disable_notification", disableNotification)
 	}
-	if url, ok := opts["url"].(string); ok {
-		callback.URL = url
+	if replyTo, ok := asInt(opts["reply_to_message_id"]); ok {
+		params.AddNonZero("reply_to_message_id", replyTo)
 	}
-	if cacheTime, ok := opts["cache_time"].(int); ok {
-		callback.CacheTime = cacheTime
+	extra, err := extraParams(opts)
+	if err != nil {
+		return nil, err
 	}
+	addExtraParams(params, extra)
 
-	_, err := c.bot.Request(callback)
-	return c.wrapError(err)
+	var resp *tgbotapi.APIResponse
+	err = c.withSender(ctx, chatID, func(bot *tgbotapi.BotAPI) error {
+		var err error
+		if len(files) > 0 {
+			resp, err = bot.UploadFiles("sendMediaGroup", params, files)
+		} else {
+			resp, err = bot.MakeRequest("sendMediaGroup", params)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	var sent []sentMessage
+	if err := json.Unmarshal(resp.Result, &sent); err != nil {
+		return nil, fmt.Errorf("failed to decode media group response: %w", err)
+	}
+
+	messages := make([]*Message, 0, len(sent))
+	for i := range sent {
+		messages = append(messages, convertSentMessage(&sent[i]))
+	}
+	return messages, nil
 }
 
-// GetFile gets file info by file_id
-func (c *Client) GetFile(ctx context.Context, fileID string) (*FileResponse, error) {
+// ForwardMessage forwards a message from one chat to another
+func (c *Client) ForwardMessage(ctx context.Context, toChatID, fromChatID, messageID int64, opts map[string]interface{}) (*Message, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	file, err := c.bot.GetFile(tgbotapi.FileConfig{FileID: fileID})
+	msg := tgbotapi.NewForward(toChatID, fromChatID, int(messageID))
+
+	applyBaseOptions(&msg.BaseChat, opts)
+
+	sent, err := c.send(ctx, msg, opts)
 	if err != nil {
 		return nil, c.wrapError(err)
 	}
 
-	return &FileResponse{
-		FileID:       file.FileID,
-		FileUniqueID: file.FileUniqueID,
-		FileSize:     int64(file.FileSize),
-		FilePath:     file.FilePath,
-	}, nil
-}
-
-// GetFileURL returns URL to download file
-func (c *Client) GetFileURL(filePath string) string {
-	return fmt.Sprintf("https://api.telegram.org/file/bot%s/%s", c.token, filePath)
+	return convertSentMessage(&sent), nil
 }
 
-// SetWebhook sets webhook URL
-func (c *Client) SetWebhook(ctx context.Context, url string, opts map[string]interface{}) error {
+// CopyMessage copies a message from one chat to another without a link to the original
+// Returns ID of the copied message. Caption can be replaced via "caption" and "parse_mode" options
+func (c *Client) CopyMessage(ctx context.Context, toChatID, fromChatID, messageID int64, opts map[string]interface{}) (int64, error) {
 	if err := c.initBot(); err != nil {
-		return err
+		return 0, err
 	}
 
-	webhook, err := tgbotapi.NewWebhook(url)
+	ctx = callContext(ctx, opts)
+
+	msg := tgbotapi.NewCopyMessage(toChatID, fromChatID, int(messageID))
+
+	applyBaseOptions(&msg.BaseChat, opts)
+	if caption, ok := opts["caption"].(string); ok {
+		msg.Caption = caption
+	}
+	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return 0, err
+		}
+		msg.ParseMode = parseMode
+	}
+	extra, err := extraParams(opts)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if maxConnections, ok := opts["max_connections"].(int); ok {
-		webhook.MaxConnections = maxConnections
+	var copied tgbotapi.MessageID
+	err = c.withSender(ctx, toChatID, func(bot *tgbotapi.BotAPI) error {
+		var err error
+		copied, err = withExtraParams(bot, extra).CopyMessage(msg)
+		return err
+	})
+	if err != nil {
+		return 0, c.wrapError(err)
 	}
 
-	_, err = c.bot.Request(webhook)
-	return c.wrapError(err)
+	return int64(copied.MessageID), nil
 }
 
-// DeleteWebhook deletes webhook
-func (c *Client) DeleteWebhook(ctx context.Context, dropPending bool) error {
-	if err := c.initBot(); err != nil {
-		return err
-	}
+// Chat actions for SendChatAction
+const (
+	ChatActionTyping          = "typing"
+	ChatActionUploadPhoto     = "upload_photo"
+	ChatActionRecordVideo     = "record_video"
+	ChatActionUploadVideo     = "upload_video"
+	ChatActionRecordVoice     = "record_voice"
+	ChatActionUploadVoice     = "upload_voice"
+	ChatActionUploadDocument  = "upload_document"
+	ChatActionChooseSticker   = "choose_sticker"
+	ChatActionFindLocation    = "find_location"
+	ChatActionRecordVideoNote = "record_video_note"
+	ChatActionUploadVideoNote = "upload_video_note"
+)
 
-	_, err := c.bot.Request(tgbotapi.DeleteWebhookConfig{
-		DropPendingUpdates: dropPending,
-	})
-	return c.wrapError(err)
-}
+// SendChatAction sends a chat action (typing, upload_photo, etc.)
+// Returns ErrInvalidChatAction if action is not one of the ChatAction constants
+// Supported options: message_thread_id, business_connection_id
+func (c *Client) SendChatAction(ctx context.Context, chatID int64, action string, opts map[string]interface{}) error {
+	switch action {
+	case ChatActionTyping, ChatActionUploadPhoto, ChatActionRecordVideo, ChatActionUploadVideo,
+		ChatActionRecordVoice, ChatActionUploadVoice, ChatActionUploadDocument, ChatActionChooseSticker,
+		ChatActionFindLocation, ChatActionRecordVideoNote, ChatActionUploadVideoNote:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidChatAction, action)
+	}
 
-// GetMe returns bot info
-func (c *Client) GetMe(ctx context.Context) (*User, error) {
 	if err := c.initBot(); err != nil {
-		return nil, err
+		return err
 	}
 
-	user, err := c.bot.GetMe()
+	ctx = callContext(ctx, opts)
+
+	extra, err := extraParams(opts)
 	if err != nil {
-		return nil, c.wrapError(err)
+		return err
 	}
 
-	return &User{
-		ID:           user.ID,
-		IsBot:        user.IsBot,
-		FirstName:    user.FirstName,
-		LastName:     user.LastName,
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params["action"] = action
+	addExtraParams(params, extra)
+
+	_, err = c.botFor(ctx).MakeRequest("sendChatAction", params)
+	return c.wrapError(err)
+}
+
+// chatActionInterval is how often StartChatAction repeats the action,
+// Telegram clears it after about 5 seconds
+const chatActionInterval = 4 * time.Second
+
+// StartChatAction keeps sending a chat action until stop is called or ctx is cancelled
+// The action is sent right away and then every 4 seconds. Repeating stops early
+// if Telegram rejects the action with anything but a rate limit error.
+// Supports the options of SendChatAction
+func (c *Client) StartChatAction(ctx context.Context, chatID int64, action string, opts map[string]interface{}) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(chatActionInterval)
+		defer ticker.Stop()
+
+		for {
+			err := c.SendChatAction(ctx, chatID, action, opts)
+			if err != nil && !IsRateLimitError(err) {
+				if c.logger != nil {
+					c.logger.Debug("stopped repeating chat action",
+						zap.Int64("chat_id", chatID),
+						zap.String("action", action),
+						zap.Error(err),
+					)
+				}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// EditMessageText edits text of a message
+// With the ignore_not_modified option an edit to the same content is not an error,
+// the returned message then has only the ID, chat and new text set
+func (c *Client) EditMessageText(ctx context.Context, chatID int64, messageID int64, text string, opts map[string]interface{}) (*Message, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	ctx = callContext(ctx, opts)
+
+	msg := tgbotapi.NewEditMessageText(chatID, int(messageID), text)
+
+	if parseMode, ok := opts["parse_mode"].(string); ok {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
+		msg.ParseMode = parseMode
+	}
+	if disablePreview, ok := opts["disable_web_page_preview"].(bool); ok {
+		msg.DisableWebPagePreview = disablePreview
+	}
+	extra, err := inlineMarkupParams(opts["reply_markup"])
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := requestMessage(withExtraParams(c.botFor(ctx), extra), msg)
+	if err != nil {
+		return notModifiedMessage(c.wrapError(err), opts, &Message{MessageID: messageID, Chat: Chat{ID: chatID}, Text: text})
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// EditMessageCaption edits caption of a media message
+// Supports the ignore_not_modified option of EditMessageText
+func (c *Client) EditMessageCaption(ctx context.Context, chatID int64, messageID int64, caption string, opts map[string]interface{}) (*Message, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	ctx = callContext(ctx, opts)
+
+	parseMode, _ := opts["parse_mode"].(string)
+	if err := validateParseMode(parseMode); err != nil {
+		return nil, err
+	}
+	caption, err := fitCaption(caption, parseMode)
+	if err != nil {
+		return nil, err
+	}
+	msg := tgbotapi.NewEditMessageCaption(chatID, int(messageID), caption)
+	msg.ParseMode = parseMode
+	extra, err := inlineMarkupParams(opts["reply_markup"])
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := requestMessage(withExtraParams(c.botFor(ctx), extra), msg)
+	if err != nil {
+		return notModifiedMessage(c.wrapError(err), opts, &Message{MessageID: messageID, Chat: Chat{ID: chatID}, Caption: caption})
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// EditMessageMedia replaces media of a message
+// Supports the ignore_not_modified option of EditMessageText
+func (c *Client) EditMessageMedia(ctx context.Context, chatID int64, messageID int64, media InputMedia, opts map[string]interface{}) (*Message, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	ctx = callContext(ctx, opts)
+
+	if parseMode, ok := opts["parse_mode"].(string); ok && media.ParseMode == "" {
+		if err := validateParseMode(parseMode); err != nil {
+			return nil, err
+		}
+		media.ParseMode = parseMode
+	}
+
+	inputMedia, err := media.inputMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := tgbotapi.EditMessageMediaConfig{
+		BaseEdit: tgbotapi.BaseEdit{
+			ChatID:    chatID,
+			MessageID: int(messageID),
+		},
+		Media: inputMedia,
+	}
+
+	extra, err := inlineMarkupParams(opts["reply_markup"])
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := requestMessage(withExtraParams(c.botFor(ctx), extra), msg)
+	if err != nil {
+		return notModifiedMessage(c.wrapError(err), opts, &Message{MessageID: messageID, Chat: Chat{ID: chatID}})
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// notModifiedMessage turns a "message is not modified" error into success if ignore_not_modified is set
+// Telegram doesn't return the message in this case, so the known fields of it are returned
+func notModifiedMessage(err error, opts map[string]interface{}, msg *Message) (*Message, error) {
+	if ignore, _ := opts["ignore_not_modified"].(bool); ignore && IsNotModifiedError(err) {
+		return msg, nil
+	}
+	return nil, err
+}
+
+// EditMessageReplyMarkup replaces inline keyboard of a message
+func (c *Client) EditMessageReplyMarkup(ctx context.Context, chatID int64, messageID int64, markup InlineKeyboardMarkup) (*Message, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	msg := tgbotapi.EditMessageReplyMarkupConfig{
+		BaseEdit: tgbotapi.BaseEdit{
+			ChatID:    chatID,
+			MessageID: int(messageID),
+		},
+	}
+	extra, err := inlineMarkupParams(markup)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := requestMessage(withExtraParams(c.botFor(ctx), extra), msg)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// EditInlineMessageReplyMarkup replaces inline keyboard of a message sent via inline mode
+func (c *Client) EditInlineMessageReplyMarkup(ctx context.Context, inlineMessageID string, markup InlineKeyboardMarkup) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	msg := tgbotapi.EditMessageReplyMarkupConfig{
+		BaseEdit: tgbotapi.BaseEdit{
+			InlineMessageID: inlineMessageID,
+		},
+	}
+	extra, err := inlineMarkupParams(markup)
+	if err != nil {
+		return err
+	}
+
+	// Telegram returns true instead of a message for inline messages
+	_, err = withExtraParams(c.botFor(ctx), extra).Request(msg)
+	return c.wrapError(err)
+}
+
+// SetMessageReaction changes reactions of the bot on a message
+// Empty reactions clear the bot's reactions, isBig shows the reaction with a big animation
+func (c *Client) SetMessageReaction(ctx context.Context, chatID int64, messageID int64, reactions []ReactionType, isBig bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	return c.setMessageReaction(ctx, chatID, messageID, reactions, isBig)
+}
+
+// setMessageReaction makes setMessageReaction request
+// tgbotapi has no config for setMessageReaction, so the request is made directly
+func (c *Client) setMessageReaction(ctx context.Context, chatID int64, messageID int64, reactions []ReactionType, isBig bool) error {
+	if reactions == nil {
+		// Telegram expects an empty list to remove reactions
+		reactions = []ReactionType{}
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero64("message_id", messageID)
+	params.AddBool("is_big", isBig)
+	if err := params.AddInterface("reaction", reactions); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).MakeRequest("setMessageReaction", params)
+	return c.wrapError(err)
+}
+
+// DeleteMessage deletes a message
+func (c *Client) DeleteMessage(ctx context.Context, chatID int64, messageID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewDeleteMessage(chatID, int(messageID))
+	_, err := c.botFor(ctx).Request(msg)
+	return c.wrapError(err)
+}
+
+// maxDeleteMessages is the maximum number of messages deleteMessages accepts at once
+const maxDeleteMessages = 100
+
+// DeleteMessages deletes several messages of a chat
+// Message IDs are sent in chunks of 100, the first failed chunk stops deletion.
+// Messages that can't be found or deleted are skipped by Telegram
+func (c *Client) DeleteMessages(ctx context.Context, chatID int64, messageIDs []int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	bot := c.botFor(ctx)
+	for start := 0; start < len(messageIDs); start += maxDeleteMessages {
+		end := start + maxDeleteMessages
+		if end > len(messageIDs) {
+			end = len(messageIDs)
+		}
+
+		params := make(tgbotapi.Params)
+		params.AddNonZero64("chat_id", chatID)
+		if err := params.AddInterface("message_ids", messageIDs[start:end]); err != nil {
+			return err
+		}
+
+		if _, err := bot.MakeRequest("deleteMessages", params); err != nil {
+			return c.wrapError(err)
+		}
+	}
+	return nil
+}
+
+// maxBulkMessages is the max number of messages forwarded or copied by one request
+const maxBulkMessages = 100
+
+// ForwardMessages forwards several messages of a chat, e.g. to migrate a history
+// Telegram needs message IDs in strictly increasing order, so they are sorted,
+// deduplicated and sent in chunks of 100. Returns IDs of the sent messages in that
+// order, messages that can't be found or forwarded are skipped. The first failed
+// chunk stops forwarding, IDs sent before it are returned with the error.
+// Supported options: disable_notification, protect_content, message_thread_id
+func (c *Client) ForwardMessages(ctx context.Context, toChatID, fromChatID int64, messageIDs []int64, opts map[string]interface{}) ([]int64, error) {
+	return c.bulkMessages(ctx, "forwardMessages", toChatID, fromChatID, messageIDs, opts)
+}
+
+// CopyMessages copies several messages of a chat without links to the originals
+// Works like ForwardMessages and also supports the remove_caption option
+func (c *Client) CopyMessages(ctx context.Context, toChatID, fromChatID int64, messageIDs []int64, opts map[string]interface{}) ([]int64, error) {
+	return c.bulkMessages(ctx, "copyMessages", toChatID, fromChatID, messageIDs, opts)
+}
+
+// bulkMessages calls forwardMessages or copyMessages in chunks of maxBulkMessages
+func (c *Client) bulkMessages(ctx context.Context, method string, toChatID, fromChatID int64, messageIDs []int64, opts map[string]interface{}) ([]int64, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	ctx = callContext(ctx, opts)
+	extra, err := extraParams(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := append([]int64(nil), messageIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	// IDs have to be strictly increasing, a repeated one fails the whole chunk
+	unique := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			unique = append(unique, id)
+		}
+	}
+	ids = unique
+
+	sentIDs := make([]int64, 0, len(ids))
+	for start := 0; start < len(ids); start += maxBulkMessages {
+		end := start + maxBulkMessages
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		params := make(tgbotapi.Params)
+		params.AddNonZero64("chat_id", toChatID)
+		params.AddNonZero64("from_chat_id", fromChatID)
+		if err := params.AddInterface("message_ids", ids[start:end]); err != nil {
+			return sentIDs, err
+		}
+		if disableNotification, ok := opts["disable_notification"].(bool); ok {
+			params.AddBool("disable_notification", disableNotification)
+		}
+		if removeCaption, ok := opts["remove_caption"].(bool); ok && method == "copyMessages" {
+			params.AddBool("remove_caption", removeCaption)
+		}
+		addExtraParams(params, extra)
+
+		var chunk []tgbotapi.MessageID
+		err := c.withSender(ctx, toChatID, func(bot *tgbotapi.BotAPI) error {
+			resp, err := bot.MakeRequest(method, params)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(resp.Result, &chunk)
+		})
+		if err != nil {
+			return sentIDs, c.wrapError(err)
+		}
+
+		for _, id := range chunk {
+			sentIDs = append(sentIDs, int64(id.MessageID))
+		}
+	}
+	return sentIDs, nil
+}
+
+// AnswerCallbackQuery answers a callback query
+// Supported options: text, show_alert, url, cache_time. Text over MaxCallbackAnswerLength
+// is truncated for toasts and returns ErrCallbackAlertTooLong for alerts. url has to be
+// a t.me link here, use AnswerGameCallback to open games
+func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackQueryID string, opts map[string]interface{}) error {
+	if url, ok := opts["url"].(string); ok && url != "" && !isTelegramLink(url) {
+		return fmt.Errorf("%w: %q", ErrCallbackURLNotAllowed, url)
+	}
+	return c.answerCallback(ctx, callbackQueryID, opts)
+}
+
+// AnswerGameCallback answers the callback query of a game button with the game URL
+func (c *Client) AnswerGameCallback(ctx context.Context, query *CallbackQuery, url string) error {
+	if query == nil || !query.IsGame() {
+		return fmt.Errorf("%w: not a game callback query", ErrCallbackURLNotAllowed)
+	}
+	return c.answerCallback(ctx, query.ID, map[string]interface{}{"url": url})
+}
+
+// answerCallback answers a callback query without checking the url option
+func (c *Client) answerCallback(ctx context.Context, callbackQueryID string, opts map[string]interface{}) error {
+	callback := tgbotapi.NewCallback(callbackQueryID, "")
+	callback.CacheTime = c.callbackCacheTime
+
+	if text, ok := opts["text"].(string); ok {
+		callback.Text = text
+	}
+	if showAlert, ok := opts["show_alert"].(bool); ok {
+		callback.ShowAlert = showAlert
+	}
+	if url, ok := opts["url"].(string); ok {
+		callback.URL = url
+	}
+	if cacheTime, ok := asInt(opts["cache_time"]); ok {
+		callback.CacheTime = cacheTime
+	}
+
+	if n := utf16Len(callback.Text); n > MaxCallbackAnswerLength {
+		if callback.ShowAlert {
+			return fmt.Errorf("%w: %d characters, max is %d", ErrCallbackAlertTooLong, n, MaxCallbackAnswerLength)
+		}
+		callback.Text = TruncateUTF16(callback.Text, MaxCallbackAnswerLength)
+	}
+
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(callback)
+	return c.wrapError(err)
+}
+
+// isTelegramLink reports whether rawURL points to t.me, e.g. a bot deep link
+func isTelegramLink(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Host)
+	return host == "t.me" || host == "telegram.me"
+}
+
+// AnswerCallbackToast answers a callback query with a notification shown at the top of the chat
+func (c *Client) AnswerCallbackToast(ctx context.Context, callbackQueryID, text string) error {
+	return c.AnswerCallbackQuery(ctx, callbackQueryID, map[string]interface{}{"text": text})
+}
+
+// AnswerCallbackAlert answers a callback query with an alert the user has to close
+func (c *Client) AnswerCallbackAlert(ctx context.Context, callbackQueryID, text string) error {
+	return c.AnswerCallbackQuery(ctx, callbackQueryID, map[string]interface{}{
+		"text":       text,
+		"show_alert": true,
+	})
+}
+
+// AnswerCallbackDismiss answers a callback query without a notification
+// Every callback query has to be answered, otherwise the button keeps showing a loading spinner
+func (c *Client) AnswerCallbackDismiss(ctx context.Context, callbackQueryID string) error {
+	return c.AnswerCallbackQuery(ctx, callbackQueryID, nil)
+}
+
+// GetFile gets file info by file_id
+func (c *Client) GetFile(ctx context.Context, fileID string) (*FileResponse, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	file, err := c.botFor(ctx).GetFile(tgbotapi.FileConfig{FileID: fileID})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return &FileResponse{
+		FileID:       file.FileID,
+		FileUniqueID: file.FileUniqueID,
+		FileSize:     int64(file.FileSize),
+		FilePath:     file.FilePath,
+	}, nil
+}
+
+// GetFileURL returns URL to download file
+// The URL contains the bot token, don't log it or pass it to clients
+func (c *Client) GetFileURL(filePath string) string {
+	c.mu.RLock()
+	token := c.token
+	c.mu.RUnlock()
+	return fmt.Sprintf(c.fileEndpoint, token, filePath)
+}
+
+// DownloadFile downloads file content by file_id
+func (c *Client) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	body, err := c.DownloadFileReader(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// DownloadFileReader downloads file by file_id and returns its content as a stream
+// The caller must close the returned reader
+func (c *Client) DownloadFileReader(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	file, err := c.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.FileSize > maxDownloadSize {
+		return nil, fmt.Errorf("file is too big to download: %d bytes, bot API limit is %d bytes", file.FileSize, maxDownloadSize)
+	}
+	if file.FilePath == "" {
+		return nil, fmt.Errorf("file %s has no file_path, it can't be downloaded", fileID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.GetFileURL(file.FilePath), nil)
+	if err != nil {
+		return nil, errors.New("failed to create download request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// url.Error contains the download URL with the bot token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download file: unexpected status %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// SetWebhook sets webhook URL
+// Set "secret_token" option to make Telegram send it in X-Telegram-Bot-Api-Secret-Token header,
+// see ValidateWebhookSecret. Other options: max_connections, allowed_updates ([]string),
+// drop_pending_updates, ip_address and certificate (FileSource with a self-signed public key)
+func (c *Client) SetWebhook(ctx context.Context, webhookURL string, opts map[string]interface{}) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	if _, err := url.Parse(webhookURL); err != nil {
+		return err
+	}
+
+	// tgbotapi doesn't support secret_token, so the request is made directly
+	params := make(tgbotapi.Params)
+	params["url"] = webhookURL
+	if maxConnections, ok := asInt(opts["max_connections"]); ok {
+		params.AddNonZero("max_connections", maxConnections)
+	}
+	if secretToken, ok := opts["secret_token"].(string); ok {
+		params.AddNonEmpty("secret_token", secretToken)
+	}
+	if ipAddress, ok := opts["ip_address"].(string); ok {
+		params.AddNonEmpty("ip_address", ipAddress)
+	}
+	if dropPending, ok := opts["drop_pending_updates"].(bool); ok {
+		params.AddBool("drop_pending_updates", dropPending)
+	}
+	if allowedUpdates, ok := opts["allowed_updates"].([]string); ok {
+		// An empty list is sent as is, Telegram resets it to all update types
+		if err := params.AddInterface("allowed_updates", allowedUpdates); err != nil {
+			return err
+		}
+	}
+
+	bot := c.botFor(ctx)
+	var err error
+	if certificate, ok := opts["certificate"].(FileSource); ok {
+		_, err = bot.UploadFiles("setWebhook", params, []tgbotapi.RequestFile{
+			{Name: "certificate", Data: certificate.requestFileData()},
+		})
+	} else {
+		_, err = bot.MakeRequest("setWebhook", params)
+	}
+	return c.wrapError(err)
+}
+
+// DeleteWebhook deletes webhook
+func (c *Client) DeleteWebhook(ctx context.Context, dropPending bool) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.DeleteWebhookConfig{
+		DropPendingUpdates: dropPending,
+	})
+	return c.wrapError(err)
+}
+
+// GetWebhookInfo returns the current webhook state
+// A growing PendingUpdateCount or a set LastErrorMessage usually means the endpoint is down
+func (c *Client) GetWebhookInfo(ctx context.Context) (*WebhookInfo, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	info, err := c.botFor(ctx).GetWebhookInfo()
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return &WebhookInfo{
+		URL:                  info.URL,
+		HasCustomCertificate: info.HasCustomCertificate,
+		PendingUpdateCount:   info.PendingUpdateCount,
+		IPAddress:            info.IPAddress,
+		LastErrorDate:        int64(info.LastErrorDate),
+		LastErrorMessage:     info.LastErrorMessage,
+		MaxConnections:       info.MaxConnections,
+		AllowedUpdates:       info.AllowedUpdates,
+	}, nil
+}
+
+// GetMe returns bot info
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	user, err := c.botFor(ctx).GetMe()
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return &User{
+		ID:           user.ID,
+		IsBot:        user.IsBot,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
 		Username:     user.UserName,
 		LanguageCode: user.LanguageCode,
 	}, nil
 }
 
 // Call makes a raw API call with any method and parameters
-// This method exists for backward compatibility
+// Numbers and booleans are sent even when zero, slices, maps and structs are JSON encoded.
+// This method exists for backward compatibility, see also CallRaw
 func (c *Client) Call(ctx context.Context, method string, params map[string]interface{}) (*Response, error) {
 	if err := c.initBot(); err != nil {
 		return nil, err
 	}
 
-	// Convert params to JSON for tgbotapi Params
+	// Convert params to tgbotapi Params, zero values are sent as is
 	tgParams := make(tgbotapi.Params)
 	for k, v := range params {
 		switch val := v.(type) {
+		case nil:
+			continue
 		case string:
 			tgParams[k] = val
 		case int:
-			tgParams.AddNonZero(k, val)
+			tgParams[k] = strconv.Itoa(val)
 		case int64:
-			tgParams.AddNonZero64(k, val)
+			tgParams[k] = strconv.FormatInt(val, 10)
 		case float64:
-			tgParams.AddNonZeroFloat(k, val)
+			tgParams[k] = strconv.FormatFloat(val, 'f', -1, 64)
 		case bool:
-			tgParams.AddBool(k, val)
+			tgParams[k] = strconv.FormatBool(val)
 		default:
-			// For complex types, marshal to JSON
+			// For complex types (slices, maps, structs), marshal to JSON
 			jsonBytes, err := json.Marshal(val)
-			if err == nil {
-				tgParams[k] = string(jsonBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode param %q: %w", k, err)
 			}
+			tgParams[k] = string(jsonBytes)
 		}
 	}
 
 	start := time.Now()
-	resp, err := c.bot.MakeRequest(method, tgParams)
+	resp, err := c.botFor(ctx).MakeRequest(method, tgParams)
 	duration := time.Since(start)
 
 	if c.logger != nil {
@@ -652,6 +1688,75 @@ func (c *Client) Call(ctx context.Context, method string, params map[string]inte
 	}, nil
 }
 
+// CallRaw makes an API call with a JSON request body, for full control over parameters
+// The request takes the same path as Call. Returns APIError if Telegram responds with ok=false
+func (c *Client) CallRaw(ctx context.Context, method string, jsonBody []byte) (*Response, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = c.baseContext()
+	}
+
+	bot := c.botFor(ctx)
+	endpoint := fmt.Sprintf(c.apiEndpoint, bot.Token, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	httpResp, err := bot.Client.Do(req)
+	duration := time.Since(start)
+
+	if c.logger != nil {
+		c.logger.Debug("telegram API response",
+			zap.String("method", method),
+			zap.Duration("tg_api_duration", duration),
+			zap.Bool("success", err == nil),
+		)
+	}
+
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp tgbotapi.APIResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if !resp.Ok {
+		apiErr := &APIError{Code: resp.ErrorCode, Description: resp.Description}
+		if resp.Parameters != nil {
+			apiErr.RetryAfter = resp.Parameters.RetryAfter
+		}
+		return nil, apiErr
+	}
+
+	return &Response{
+		OK:     resp.Ok,
+		Result: resp.Result,
+	}, nil
+}
+
+// CallInto makes an API call and decodes the result into T
+// Returns APIError if Telegram responds with ok=false
+func CallInto[T any](ctx context.Context, c *Client, method string, params map[string]any) (T, error) {
+	var result T
+
+	resp, err := c.Call(ctx, method, params)
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return result, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return result, nil
+}
+
 // wrapError converts tgbotapi errors to APIError
 func (c *Client) wrapError(err error) error {
 	if err == nil {
@@ -663,19 +1768,23 @@ func (c *Client) wrapError(err error) error {
 		return &APIError{
 			Code:        tgErr.Code,
 			Description: tgErr.Message,
+			RetryAfter:  tgErr.RetryAfter,
 		}
 	}
 
-	return err
+	return redactError(err, c.tokens())
 }
 
 // Helper functions
 
+// applyBaseOptions applies options shared by all send methods
+// message_thread_id, protect_content and business_connection_id have no BaseChat
+// fields in tgbotapi, send applies them separately, see extraParams
 func applyBaseOptions(base *tgbotapi.BaseChat, opts map[string]interface{}) {
 	if disableNotification, ok := opts["disable_notification"].(bool); ok {
 		base.DisableNotification = disableNotification
 	}
-	if replyTo, ok := opts["reply_to_message_id"].(int); ok {
+	if replyTo, ok := asInt(opts["reply_to_message_id"]); ok {
 		base.ReplyToMessageID = replyTo
 	}
 	if replyMarkup, ok := opts["reply_markup"]; ok {
@@ -683,8 +1792,292 @@ func applyBaseOptions(base *tgbotapi.BaseChat, opts map[string]interface{}) {
 	}
 }
 
-func applyMediaOptions(base *tgbotapi.BaseChat, caption *string, opts map[string]interface{}) {
+// asInt reads an integer option value
+// Accepts int, int64 and float64, since numbers decoded from JSON are float64
+func asInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, false
+		}
+		return int(n), true
+	}
+	return 0, false
+}
+
+// asFloat reads a floating point option value
+func asFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// applyMediaOptions applies base options and fits the caption into MaxCaptionLength, see fitCaption
+func applyMediaOptions(base *tgbotapi.BaseChat, caption *string, opts map[string]interface{}) error {
 	applyBaseOptions(base, opts)
+	parseMode, _ := opts["parse_mode"].(string)
+	fitted, err := fitCaption(*caption, parseMode)
+	if err != nil {
+		return err
+	}
+	*caption = fitted
+	return nil
+}
+
+// thumbnailOption reads the "thumbnail" FileSource option, nil if it is not set
+func thumbnailOption(opts map[string]interface{}) tgbotapi.RequestFileData {
+	if thumbnail, ok := opts["thumbnail"].(FileSource); ok {
+		return thumbnail.requestFileData()
+	}
+	return nil
+}
+
+// convertEntities converts tgbotapi message entities to our type
+// CustomEmojiID is unknown to tgbotapi, convertSentMessage fills it
+func convertEntities(entities []tgbotapi.MessageEntity) []MessageEntity {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	result := make([]MessageEntity, 0, len(entities))
+	for _, e := range entities {
+		result = append(result, MessageEntity{
+			Type:     e.Type,
+			Offset:   e.Offset,
+			Length:   e.Length,
+			URL:      e.URL,
+			User:     convertUser(e.User),
+			Language: e.Language,
+		})
+	}
+	return result
+}
+
+// convertEntitiesToTg converts message entities to tgbotapi format
+func convertEntitiesToTg(entities []MessageEntity) []tgbotapi.MessageEntity {
+	result := make([]tgbotapi.MessageEntity, 0, len(entities))
+	for _, e := range entities {
+		entity := tgbotapi.MessageEntity{
+			Type:     e.Type,
+			Offset:   e.Offset,
+			Length:   e.Length,
+			URL:      e.URL,
+			Language: e.Language,
+		}
+		if e.User != nil {
+			entity.User = &tgbotapi.User{ID: e.User.ID, FirstName: e.User.FirstName}
+		}
+		result = append(result, entity)
+	}
+	return result
+}
+
+// convertPoll converts tgbotapi.Poll to our Poll type
+func convertPoll(poll *tgbotapi.Poll) *Poll {
+	if poll == nil {
+		return nil
+	}
+
+	result := &Poll{
+		ID:                    poll.ID,
+		Question:              poll.Question,
+		TotalVoterCount:       poll.TotalVoterCount,
+		IsClosed:              poll.IsClosed,
+		IsAnonymous:           poll.IsAnonymous,
+		Type:                  poll.Type,
+		AllowsMultipleAnswers: poll.AllowsMultipleAnswers,
+		CorrectOptionID:       poll.CorrectOptionID,
+		Explanation:           poll.Explanation,
+		ExplanationEntities:   convertEntities(poll.ExplanationEntities),
+	}
+	for _, opt := range poll.Options {
+		result.Options = append(result.Options, PollOption{
+			Text:       opt.Text,
+			VoterCount: opt.VoterCount,
+		})
+	}
+	return result
+}
+
+// convertUser converts tgbotapi.User to our User type
+func convertUser(u *tgbotapi.User) *User {
+	if u == nil {
+		return nil
+	}
+
+	return &User{
+		ID:           u.ID,
+		IsBot:        u.IsBot,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Username:     u.UserName,
+		LanguageCode: u.LanguageCode,
+	}
+}
+
+// convertChat converts tgbotapi chat to our type
+func convertChat(c *tgbotapi.Chat) *Chat {
+	if c == nil {
+		return nil
+	}
+
+	return &Chat{
+		ID:        c.ID,
+		Type:      c.Type,
+		Title:     c.Title,
+		Username:  c.UserName,
+		FirstName: c.FirstName,
+		LastName:  c.LastName,
+	}
+}
+
+// convertForwardOrigin builds forward origin from legacy forward fields of tgbotapi message
+// Returns nil if the message is not forwarded
+func convertForwardOrigin(msg *tgbotapi.Message) *MessageOrigin {
+	if msg.ForwardDate == 0 {
+		return nil
+	}
+
+	origin := &MessageOrigin{Date: int64(msg.ForwardDate)}
+	switch {
+	case msg.ForwardFrom != nil:
+		origin.Type = "user"
+		origin.SenderUser = convertUser(msg.ForwardFrom)
+	case msg.ForwardFromChat != nil && msg.ForwardFromChat.Type == "channel":
+		origin.Type = "channel"
+		origin.Chat = convertChat(msg.ForwardFromChat)
+		origin.MessageID = int64(msg.ForwardFromMessageID)
+		origin.AuthorSignature = msg.ForwardSignature
+	case msg.ForwardFromChat != nil:
+		origin.Type = "chat"
+		origin.SenderChat = convertChat(msg.ForwardFromChat)
+		origin.AuthorSignature = msg.ForwardSignature
+	default:
+		origin.Type = "hidden_user"
+		origin.SenderUserName = msg.ForwardSenderName
+	}
+	return origin
+}
+
+// convertPhotoSize converts tgbotapi PhotoSize to our type
+func convertPhotoSize(p *tgbotapi.PhotoSize) *PhotoSize {
+	if p == nil {
+		return nil
+	}
+	return &PhotoSize{
+		FileID:       p.FileID,
+		FileUniqueID: p.FileUniqueID,
+		Width:        p.Width,
+		Height:       p.Height,
+		FileSize:     int64(p.FileSize),
+	}
+}
+
+// sentMessage is a message returned by a Bot API method
+// It keeps the raw JSON next to the tgbotapi fields, so fields tgbotapi
+// doesn't decode are not lost, see convertSentMessage
+type sentMessage struct {
+	tgbotapi.Message
+	raw json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (m *sentMessage) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Message); err != nil {
+		return err
+	}
+	m.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the raw JSON is returned as is
+func (m sentMessage) MarshalJSON() ([]byte, error) {
+	if m.raw != nil {
+		return m.raw, nil
+	}
+	return json.Marshal(m.Message)
+}
+
+// requestMessage makes a request that returns a message, like tgbotapi.BotAPI.Send
+func requestMessage(bot *tgbotapi.BotAPI, c tgbotapi.Chattable) (sentMessage, error) {
+	resp, err := bot.Request(c)
+	if err != nil {
+		return sentMessage{}, err
+	}
+
+	var sent sentMessage
+	err = json.Unmarshal(resp.Result, &sent)
+	return sent, err
+}
+
+// rawMessageFields are message fields tgbotapi doesn't decode
+type rawMessageFields struct {
+	Sticker         *Sticker          `json:"sticker"`
+	GiveawayCreated *GiveawayCreated  `json:"giveaway_created"`
+	BoostAdded      *ChatBoostAdded   `json:"boost_added"`
+	IsFromOffline   bool              `json:"is_from_offline"`
+	ReplyToMessage  *rawMessageFields `json:"reply_to_message"`
+	Entities        []rawEntityFields `json:"entities"`
+	CaptionEntities []rawEntityFields `json:"caption_entities"`
+}
+
+// rawEntityFields are message entity fields tgbotapi doesn't decode
+type rawEntityFields struct {
+	CustomEmojiID string `json:"custom_emoji_id"`
+}
+
+// apply sets the fields on a message converted by convertMessage
+func (f *rawMessageFields) apply(msg *Message) {
+	if f.Sticker != nil {
+		msg.Sticker = f.Sticker
+	}
+	msg.GiveawayCreated = f.GiveawayCreated
+	msg.BoostAdded = f.BoostAdded
+	msg.IsFromOffline = f.IsFromOffline
+	applyEntityFields(msg.Entities, f.Entities)
+	applyEntityFields(msg.CaptionEntities, f.CaptionEntities)
+	if f.ReplyToMessage != nil && msg.ReplyToMessage != nil {
+		f.ReplyToMessage.apply(msg.ReplyToMessage)
+	}
+}
+
+// applyEntityFields sets the raw fields on entities converted by convertEntities
+// Both slices come from the same JSON array, so they match by index
+func applyEntityFields(entities []MessageEntity, fields []rawEntityFields) {
+	for i := range entities {
+		if i < len(fields) {
+			entities[i].CustomEmojiID = fields[i].CustomEmojiID
+		}
+	}
+}
+
+// convertSentMessage converts a message returned by a Bot API method
+// Unlike convertMessage it fills the fields tgbotapi doesn't decode from the raw JSON
+func convertSentMessage(sent *sentMessage) *Message {
+	result := convertMessage(&sent.Message)
+	if len(sent.raw) == 0 {
+		return result
+	}
+
+	var fields rawMessageFields
+	if err := json.Unmarshal(sent.raw, &fields); err == nil {
+		fields.apply(result)
+	}
+	return result
 }
 
 // convertMessage converts tgbotapi.Message to our Message type
@@ -698,24 +2091,32 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 		Date:      int64(msg.Date),
 		Text:      msg.Text,
 		Caption:   msg.Caption,
-		Chat: Chat{
-			ID:        msg.Chat.ID,
-			Type:      msg.Chat.Type,
-			Title:     msg.Chat.Title,
-			Username:  msg.Chat.UserName,
-			FirstName: msg.Chat.FirstName,
-			LastName:  msg.Chat.LastName,
-		},
+	}
+	if chat := convertChat(msg.Chat); chat != nil {
+		result.Chat = *chat
 	}
 
-	if msg.From != nil {
-		result.From = &User{
-			ID:           msg.From.ID,
-			IsBot:        msg.From.IsBot,
-			FirstName:    msg.From.FirstName,
-			LastName:     msg.From.LastName,
-			Username:     msg.From.UserName,
-			LanguageCode: msg.From.LanguageCode,
+	result.From = convertUser(msg.From)
+	result.EditDate = int64(msg.EditDate)
+	result.MediaGroupID = msg.MediaGroupID
+	result.ForwardOrigin = convertForwardOrigin(msg)
+	result.ViaBot = convertUser(msg.ViaBot)
+	result.HasProtectedContent = msg.HasProtectedContent
+	// IsFromOffline is not exposed by tgbotapi, convertSentMessage takes it from the raw JSON
+
+	// Service messages
+	for i := range msg.NewChatMembers {
+		result.NewChatMembers = append(result.NewChatMembers, *convertUser(&msg.NewChatMembers[i]))
+	}
+	result.LeftChatMember = convertUser(msg.LeftChatMember)
+	result.NewChatTitle = msg.NewChatTitle
+	result.GroupChatCreated = msg.GroupChatCreated
+	result.Entities = convertEntities(msg.Entities)
+	result.CaptionEntities = convertEntities(msg.CaptionEntities)
+
+	if msg.ReplyMarkup != nil {
+		if markup, err := json.Marshal(msg.ReplyMarkup); err == nil {
+			result.ReplyMarkup = markup
 		}
 	}
 
@@ -745,6 +2146,7 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 			MimeType:     msg.Document.MimeType,
 			FileSize:     int64(msg.Document.FileSize),
 		}
+		result.Document.Thumbnail = convertPhotoSize(msg.Document.Thumbnail)
 	}
 
 	// Convert video
@@ -759,6 +2161,22 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 			MimeType:     msg.Video.MimeType,
 			FileSize:     int64(msg.Video.FileSize),
 		}
+		result.Video.Thumbnail = convertPhotoSize(msg.Video.Thumbnail)
+	}
+
+	// Convert animation
+	if msg.Animation != nil {
+		result.Animation = &Animation{
+			FileID:       msg.Animation.FileID,
+			FileUniqueID: msg.Animation.FileUniqueID,
+			Width:        msg.Animation.Width,
+			Height:       msg.Animation.Height,
+			Duration:     msg.Animation.Duration,
+			Thumbnail:    convertPhotoSize(msg.Animation.Thumbnail),
+			FileName:     msg.Animation.FileName,
+			MimeType:     msg.Animation.MimeType,
+			FileSize:     int64(msg.Animation.FileSize),
+		}
 	}
 
 	// Convert audio
@@ -773,6 +2191,7 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 			MimeType:     msg.Audio.MimeType,
 			FileSize:     int64(msg.Audio.FileSize),
 		}
+		result.Audio.Thumbnail = convertPhotoSize(msg.Audio.Thumbnail)
 	}
 
 	// Convert voice
@@ -786,7 +2205,21 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 		}
 	}
 
+	// Convert video note
+	if msg.VideoNote != nil {
+		result.VideoNote = &VideoNote{
+			FileID:       msg.VideoNote.FileID,
+			FileUniqueID: msg.VideoNote.FileUniqueID,
+			Length:       msg.VideoNote.Length,
+			Duration:     msg.VideoNote.Duration,
+			Thumbnail:    convertPhotoSize(msg.VideoNote.Thumbnail),
+			FileSize:     int64(msg.VideoNote.FileSize),
+		}
+	}
+
 	// Convert sticker
+	// tgbotapi doesn't decode type, is_video, custom_emoji_id and premium_animation,
+	// they are left empty here and taken from the raw JSON by convertSentMessage
 	if msg.Sticker != nil {
 		result.Sticker = &Sticker{
 			FileID:       msg.Sticker.FileID,
@@ -794,6 +2227,7 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 			Width:        msg.Sticker.Width,
 			Height:       msg.Sticker.Height,
 			IsAnimated:   msg.Sticker.IsAnimated,
+			Thumbnail:    convertPhotoSize(msg.Sticker.Thumbnail),
 			Emoji:        msg.Sticker.Emoji,
 			SetName:      msg.Sticker.SetName,
 			FileSize:     int64(msg.Sticker.FileSize),
@@ -834,23 +2268,7 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 	}
 
 	// Convert poll
-	if msg.Poll != nil {
-		result.Poll = &Poll{
-			ID:                    msg.Poll.ID,
-			Question:              msg.Poll.Question,
-			TotalVoterCount:       msg.Poll.TotalVoterCount,
-			IsClosed:              msg.Poll.IsClosed,
-			IsAnonymous:           msg.Poll.IsAnonymous,
-			Type:                  msg.Poll.Type,
-			AllowsMultipleAnswers: msg.Poll.AllowsMultipleAnswers,
-		}
-		for _, opt := range msg.Poll.Options {
-			result.Poll.Options = append(result.Poll.Options, PollOption{
-				Text:       opt.Text,
-				VoterCount: opt.VoterCount,
-			})
-		}
-	}
+	result.Poll = convertPoll(msg.Poll)
 
 	// Convert dice
 	if msg.Dice != nil {
@@ -860,5 +2278,11 @@ func convertMessage(msg *tgbotapi.Message) *Message {
 		}
 	}
 
+	// Convert payment
+	result.SuccessfulPayment = convertSuccessfulPayment(msg.SuccessfulPayment)
+
+	// GiveawayCreated and BoostAdded are not exposed by tgbotapi,
+	// convertSentMessage takes them from the raw JSON
+
 	return result
 }
@@ -0,0 +1,213 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// decodeSentMessage decodes a message as it is returned by a Bot API method
+func decodeSentMessage(t *testing.T, raw string) *Message {
+	t.Helper()
+	var sent sentMessage
+	if err := json.Unmarshal([]byte(raw), &sent); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	return convertSentMessage(&sent)
+}
+
+func TestConvertSentMessageSticker(t *testing.T) {
+	msg := decodeSentMessage(t, `{
+		"message_id": 1, "date": 1, "chat": {"id": 10, "type": "private"},
+		"sticker": {
+			"file_id": "f", "file_unique_id": "u", "type": "custom_emoji",
+			"width": 100, "height": 100, "is_animated": false, "is_video": true,
+			"custom_emoji_id": "5368324170671202286",
+			"premium_animation": {"file_id": "p", "file_unique_id": "pu"}
+		},
+		"reply_to_message": {
+			"message_id": 0, "date": 1, "chat": {"id": 10, "type": "private"},
+			"sticker": {"file_id": "m", "file_unique_id": "mu", "type": "mask", "width": 1, "height": 1, "is_animated": true, "is_video": false}
+		}
+	}`)
+
+	s := msg.Sticker
+	if s == nil {
+		t.Fatal("sticker is nil")
+	}
+	if s.Type != "custom_emoji" || !s.IsVideo || s.CustomEmojiID != "5368324170671202286" {
+		t.Errorf("sticker = %+v, want custom_emoji video sticker with custom_emoji_id", s)
+	}
+	if s.PremiumAnimation == nil || s.PremiumAnimation.FileID != "p" {
+		t.Errorf("premium_animation = %+v, want file p", s.PremiumAnimation)
+	}
+
+	reply := msg.ReplyToMessage
+	if reply == nil || reply.Sticker == nil || reply.Sticker.Type != "mask" || !reply.Sticker.IsAnimated {
+		t.Errorf("reply sticker = %+v, want animated mask", reply)
+	}
+}
+
+func TestConvertMessageStickerTypeUnknown(t *testing.T) {
+	var sent sentMessage
+	if err := json.Unmarshal([]byte(`{"message_id": 1, "chat": {"id": 1}, "sticker": {"file_id": "f", "type": "custom_emoji"}}`), &sent); err != nil {
+		t.Fatal(err)
+	}
+	// Without the raw JSON the type is not known and must not be made up
+	if got := convertMessage(&sent.Message).Sticker.Type; got != "" {
+		t.Errorf("Sticker.Type = %q, want empty", got)
+	}
+}
+
+func TestConvertSentMessageServiceMessages(t *testing.T) {
+	msg := decodeSentMessage(t, `{
+		"message_id": 1, "date": 1, "chat": {"id": -100, "type": "channel"},
+		"giveaway_created": {"prize_star_count": 500},
+		"reply_to_message": {"message_id": 0, "date": 1, "chat": {"id": -100, "type": "channel"}, "boost_added": {"boost_count": 4}}
+	}`)
+
+	if msg.GiveawayCreated == nil || msg.GiveawayCreated.PrizeStarCount != 500 {
+		t.Errorf("GiveawayCreated = %+v, want 500 stars", msg.GiveawayCreated)
+	}
+	if msg.BoostAdded != nil {
+		t.Errorf("BoostAdded = %+v, want nil", msg.BoostAdded)
+	}
+	if reply := msg.ReplyToMessage; reply == nil || reply.BoostAdded == nil || reply.BoostAdded.BoostCount != 4 {
+		t.Errorf("reply BoostAdded = %+v, want 4 boosts", reply)
+	}
+}
+
+func TestConvertSentMessageFromOffline(t *testing.T) {
+	msg := decodeSentMessage(t, `{
+		"message_id": 1, "date": 1, "chat": {"id": 10, "type": "private"},
+		"has_protected_content": true, "is_from_offline": true, "text": "away"
+	}`)
+
+	if !msg.IsFromOffline || !msg.HasProtectedContent {
+		t.Errorf("IsFromOffline = %v, HasProtectedContent = %v, want both true", msg.IsFromOffline, msg.HasProtectedContent)
+	}
+}
+
+func TestConvertSentMessageCustomEmoji(t *testing.T) {
+	msg := decodeSentMessage(t, `{
+		"message_id": 1, "date": 1, "chat": {"id": 10, "type": "private"},
+		"text": "hi 👍", "entities": [
+			{"type": "bold", "offset": 0, "length": 2},
+			{"type": "custom_emoji", "offset": 3, "length": 2, "custom_emoji_id": "5368324170671202286"}
+		],
+		"reply_to_message": {
+			"message_id": 2, "date": 1, "chat": {"id": 10, "type": "private"},
+			"caption": "👍", "caption_entities": [{"type": "custom_emoji", "offset": 0, "length": 2, "custom_emoji_id": "42"}]
+		}
+	}`)
+
+	if len(msg.Entities) != 2 || msg.Entities[0].CustomEmojiID != "" || msg.Entities[1].CustomEmojiID != "5368324170671202286" {
+		t.Errorf("Entities = %+v, want custom_emoji_id on the second one", msg.Entities)
+	}
+	if reply := msg.ReplyToMessage; reply == nil || len(reply.CaptionEntities) != 1 || reply.CaptionEntities[0].CustomEmojiID != "42" {
+		t.Errorf("reply CaptionEntities lost custom_emoji_id")
+	}
+}
+
+func TestEditMessageKeepsBuilderKeyboard(t *testing.T) {
+	markup := NewInlineKeyboard().
+		ButtonWebApp("Open", "https://example.com/app").
+		ButtonCopyText("Copy", "promo").
+		Build()
+
+	var got map[string]string
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got == nil {
+			got = make(map[string]string)
+		}
+		got[TestMethod(r)] = r.FormValue("reply_markup")
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}))
+
+	ctx := context.Background()
+	if _, err := client.EditMessageText(ctx, 10, 1, "text", map[string]interface{}{"reply_markup": markup}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.EditMessageReplyMarkup(ctx, 10, 1, markup); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, method := range []string{"editMessageText", "editMessageReplyMarkup"} {
+		var sent InlineKeyboardMarkup
+		if err := json.Unmarshal([]byte(got[method]), &sent); err != nil {
+			t.Fatalf("%s: invalid reply_markup %q: %v", method, got[method], err)
+		}
+		row := sent.InlineKeyboard[0]
+		if len(row) != 2 || row[0].WebApp == nil || row[1].CopyText == nil {
+			t.Errorf("%s: reply_markup = %s, want web_app and copy_text buttons", method, got[method])
+		}
+	}
+}
+
+func TestCopyMessageExtraParams(t *testing.T) {
+	var form url.Values
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.Form
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":42}}`)
+	}))
+
+	id, err := client.CopyMessage(context.Background(), 10, 20, 5, map[string]interface{}{
+		"message_thread_id":      3,
+		"protect_content":        true,
+		"business_connection_id": "conn",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 42 {
+		t.Errorf("CopyMessage() = %d, want 42", id)
+	}
+	for key, want := range map[string]string{"message_thread_id": "3", "protect_content": "true", "business_connection_id": "conn"} {
+		if got := form.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestForwardMessagesSortsAndDedupes(t *testing.T) {
+	var got []string
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.FormValue("message_ids"))
+		fmt.Fprint(w, `{"ok":true,"result":[{"message_id":1},{"message_id":2},{"message_id":3}]}`)
+	}))
+
+	if _, err := client.ForwardMessages(context.Background(), 10, 20, []int64{5, 3, 5, 9, 3}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "[3,5,9]" {
+		t.Errorf("message_ids = %v, want [[3,5,9]]", got)
+	}
+}
+
+func TestCallRawUsesCallPath(t *testing.T) {
+	var hooked []string
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}), WithRequestHook(func(ctx context.Context, method string, next func() error) error {
+		hooked = append(hooked, method)
+		return next()
+	}))
+
+	if _, err := client.CallRaw(context.Background(), "setMyName", []byte(`{"name":"bot"}`)); err != nil {
+		t.Fatalf("CallRaw() error = %v", err)
+	}
+	if len(hooked) != 1 || hooked[0] != "setMyName" {
+		t.Errorf("hooked methods = %v, want setMyName", hooked)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.CallRaw(ctx, "setMyName", []byte(`{"name":"bot"}`)); !errors.Is(err, context.Canceled) {
+		t.Errorf("CallRaw() with canceled ctx error = %v, want context.Canceled", err)
+	}
+}
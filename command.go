@@ -0,0 +1,46 @@
+package telegram
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Command returns the bot command the message starts with and the text after it
+// cmd is returned without the leading slash and the @botusername suffix used in groups,
+// so "/start@my_bot ref42" gives "start" and "ref42"
+func (m *Message) Command() (cmd string, args string, ok bool) {
+	entity, ok := m.commandEntity()
+	if !ok {
+		return "", "", false
+	}
+
+	text := utf16.Encode([]rune(m.Text))
+	end := entity.Offset + entity.Length
+	if end > len(text) {
+		return "", "", false
+	}
+
+	cmd = string(utf16.Decode(text[1:end]))
+	cmd, _, _ = strings.Cut(cmd, "@")
+	args = strings.TrimSpace(string(utf16.Decode(text[end:])))
+	return cmd, args, true
+}
+
+// IsCommand reports whether the message starts with a bot command
+func (m *Message) IsCommand() bool {
+	_, ok := m.commandEntity()
+	return ok
+}
+
+// commandEntity returns the bot_command entity at the start of the message
+func (m *Message) commandEntity() (MessageEntity, bool) {
+	if m == nil {
+		return MessageEntity{}, false
+	}
+	for _, e := range m.Entities {
+		if e.Type == "bot_command" && e.Offset == 0 && e.Length > 1 {
+			return e, true
+		}
+	}
+	return MessageEntity{}, false
+}
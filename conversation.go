@@ -0,0 +1,159 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ConversationStore stores state of multi-step conversations keyed by chat and user
+type ConversationStore interface {
+	Get(ctx context.Context, chatID, userID int64) (state string, data []byte, ok bool)
+	Set(ctx context.Context, chatID, userID int64, state string, data []byte) error
+	Clear(ctx context.Context, chatID, userID int64) error
+}
+
+// conversationKey identifies a conversation
+type conversationKey struct {
+	chatID int64
+	userID int64
+}
+
+// conversationEntry is a stored conversation state
+type conversationEntry struct {
+	state     string
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryConversationStore is an in-memory ConversationStore with TTL
+// State expires when it is not updated for ttl
+type MemoryConversationStore struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[conversationKey]conversationEntry
+	lastSweep time.Time
+}
+
+// NewMemoryConversationStore creates an in-memory store, 0 ttl keeps states forever
+func NewMemoryConversationStore(ttl time.Duration) *MemoryConversationStore {
+	return &MemoryConversationStore{
+		ttl:       ttl,
+		entries:   make(map[conversationKey]conversationEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get returns the conversation state
+func (s *MemoryConversationStore) Get(ctx context.Context, chatID, userID int64) (string, []byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := conversationKey{chatID: chatID, userID: userID}
+	entry, ok := s.entries[key]
+	if !ok {
+		return "", nil, false
+	}
+	if s.expired(entry, time.Now()) {
+		delete(s.entries, key)
+		return "", nil, false
+	}
+	return entry.state, entry.data, true
+}
+
+// Set saves the conversation state and prolongs its TTL
+func (s *MemoryConversationStore) Set(ctx context.Context, chatID, userID int64, state string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry := conversationEntry{state: state, data: data}
+	if s.ttl > 0 {
+		entry.expiresAt = now.Add(s.ttl)
+	}
+	s.entries[conversationKey{chatID: chatID, userID: userID}] = entry
+
+	// Drop abandoned conversations once per TTL period
+	if s.ttl > 0 && now.Sub(s.lastSweep) > s.ttl {
+		for key, e := range s.entries {
+			if s.expired(e, now) {
+				delete(s.entries, key)
+			}
+		}
+		s.lastSweep = now
+	}
+	return nil
+}
+
+// Clear removes the conversation state
+func (s *MemoryConversationStore) Clear(ctx context.Context, chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, conversationKey{chatID: chatID, userID: userID})
+	return nil
+}
+
+// expired reports whether the entry is expired at the given moment
+func (s *MemoryConversationStore) expired(entry conversationEntry, now time.Time) bool {
+	return !entry.expiresAt.IsZero() && now.After(entry.expiresAt)
+}
+
+// ErrNoConversationKey is returned when an update has no chat or user to key a conversation by
+var ErrNoConversationKey = errors.New("update has no chat and user to key conversation")
+
+// Conversation tracks per chat+user state of multi-step flows using a ConversationStore
+type Conversation struct {
+	store ConversationStore
+}
+
+// NewConversation creates a Conversation backed by the store
+func NewConversation(store ConversationStore) *Conversation {
+	return &Conversation{store: store}
+}
+
+// State returns the current state of the conversation the update belongs to
+func (c *Conversation) State(ctx context.Context, update *Update) (state string, data []byte, ok bool) {
+	chatID, userID, ok := conversationKeyOf(update)
+	if !ok {
+		return "", nil, false
+	}
+	return c.store.Get(ctx, chatID, userID)
+}
+
+// Advance moves the conversation the update belongs to into the next state
+func (c *Conversation) Advance(ctx context.Context, update *Update, state string, data []byte) error {
+	chatID, userID, ok := conversationKeyOf(update)
+	if !ok {
+		return ErrNoConversationKey
+	}
+	return c.store.Set(ctx, chatID, userID, state, data)
+}
+
+// End finishes the conversation the update belongs to
+func (c *Conversation) End(ctx context.Context, update *Update) error {
+	chatID, userID, ok := conversationKeyOf(update)
+	if !ok {
+		return ErrNoConversationKey
+	}
+	return c.store.Clear(ctx, chatID, userID)
+}
+
+// conversationKeyOf extracts chat and user IDs from an update
+func conversationKeyOf(update *Update) (chatID, userID int64, ok bool) {
+	if update == nil {
+		return 0, 0, false
+	}
+
+	switch {
+	case update.Message != nil && update.Message.From != nil:
+		return update.Message.Chat.ID, update.Message.From.ID, true
+	case update.EditedMessage != nil && update.EditedMessage.From != nil:
+		return update.EditedMessage.Chat.ID, update.EditedMessage.From.ID, true
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		return update.CallbackQuery.Message.Chat.ID, update.CallbackQuery.From.ID, true
+	}
+	return 0, 0, false
+}
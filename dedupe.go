@@ -0,0 +1,131 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultDedupeWindow is how long SendMessageOnce suppresses resends by default
+const defaultDedupeWindow = time.Hour
+
+// DedupeStore records keys of sent messages for SendMessageOnce
+// Implement it over Redis or a database to deduplicate across processes
+type DedupeStore interface {
+	// Claim records the key for the window and reports whether it was not recorded yet
+	// It has to be atomic, concurrent claims of one key must succeed only once
+	Claim(ctx context.Context, key string, window time.Duration) (bool, error)
+	// Release removes the key, so the message can be sent again
+	Release(ctx context.Context, key string) error
+}
+
+// WithDedupeStore sets the store and window of SendMessageOnce
+// By default keys are kept in memory of the Client for an hour
+func WithDedupeStore(store DedupeStore, window time.Duration) Option {
+	return func(c *Client) {
+		if store != nil {
+			c.dedupeStore = store
+		}
+		if window > 0 {
+			c.dedupeWindow = window
+		}
+	}
+}
+
+// SendMessageOnce sends a text message unless a message with the same dedupeKey
+// was sent to the chat within the dedupe window, then ErrDuplicateMessage is returned.
+// The key is released when the send fails before the request is written or Telegram
+// rejects the message, so the send can be fixed and retried. On transport errors after
+// the request was written the message may have been delivered, the key is kept and
+// a retry returns ErrDuplicateMessage instead of double-sending
+func (c *Client) SendMessageOnce(ctx context.Context, chatID int64, text, dedupeKey string, opts map[string]interface{}) (*Message, error) {
+	// Nothing can be sent with a done context or without a bot, don't claim the key then.
+	// A getMe failure of initBot is a transport error that isDeliveryUncertain can't tell apart
+	if ctx != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	key := strconv.FormatInt(chatID, 10) + ":" + dedupeKey
+
+	claimed, err := c.dedupeStore.Claim(ctx, key, c.dedupeWindow)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrDuplicateMessage
+	}
+
+	msg, err := c.SendMessage(ctx, chatID, text, opts)
+	if err != nil && !isDeliveryUncertain(err) {
+		// Release with a fresh context, the send may have failed because ctx is done
+		if releaseErr := c.dedupeStore.Release(c.baseContext(), key); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+	}
+	return msg, err
+}
+
+// isDeliveryUncertain reports whether a failed send may still have delivered the message
+// Only transport errors of the HTTP client are uncertain, the request may have reached
+// Telegram before the connection broke or the deadline passed. Errors returned by
+// Telegram and local failures before sending, such as bot initialization, option
+// validation or the rate limiter, mean nothing was delivered
+func isDeliveryUncertain(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// MemoryDedupeStore is an in-memory DedupeStore
+// Expired keys are removed on Claim, no background goroutine is used
+type MemoryDedupeStore struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time // Expiry of every key
+	lastPrune time.Time
+}
+
+// NewMemoryDedupeStore creates an in-memory dedupe store
+func NewMemoryDedupeStore() *MemoryDedupeStore {
+	return &MemoryDedupeStore{keys: make(map[string]time.Time)}
+}
+
+// Claim records the key unless it is recorded and not expired
+func (s *MemoryDedupeStore) Claim(ctx context.Context, key string, window time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPrune) >= window {
+		s.prune(now)
+	}
+
+	if expiresAt, ok := s.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.keys[key] = now.Add(window)
+	return true, nil
+}
+
+// Release removes the key
+func (s *MemoryDedupeStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+	return nil
+}
+
+// prune removes expired keys, must be called with mu held
+func (s *MemoryDedupeStore) prune(now time.Time) {
+	for key, expiresAt := range s.keys {
+		if !now.Before(expiresAt) {
+			delete(s.keys, key)
+		}
+	}
+	s.lastPrune = now
+}
@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// maxStartPayloadLen is the max length of the start parameter allowed by Telegram
+const maxStartPayloadLen = 64
+
+// StartLink builds a t.me deep link that opens the bot with the given payload
+// Payload is base64url encoded, so any data up to 48 bytes fits into the link
+func StartLink(botUsername, payload string) (string, error) {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	if len(encoded) > maxStartPayloadLen {
+		return "", fmt.Errorf("start payload is too long: %d bytes encoded, max is %d", len(encoded), maxStartPayloadLen)
+	}
+
+	return "https://t.me/" + strings.TrimPrefix(botUsername, "@") + "?start=" + encoded, nil
+}
+
+// StartPayload returns the decoded deep link payload of a "/start <payload>" message
+// Returns false if the message is not a /start command with a payload created by StartLink
+func (m *Message) StartPayload() (string, bool) {
+	if m == nil {
+		return "", false
+	}
+
+	command, arg, _ := strings.Cut(strings.TrimSpace(m.Text), " ")
+
+	// Strip the @botname suffix used in groups
+	command, _, _ = strings.Cut(command, "@")
+	if command != "/start" {
+		return "", false
+	}
+
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return "", false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(arg)
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
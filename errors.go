@@ -1,11 +1,56 @@
 package telegram
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrClientUnauthorized is returned by sends after the circuit breaker
+// has tripped on repeated 401 responses, see Client.ResetCircuit
+var ErrClientUnauthorized = errors.New("telegram client is unauthorized, sending is stopped")
+
+// ErrInvalidParseMode is returned when parse_mode is not one of the ParseMode constants
+var ErrInvalidParseMode = errors.New("invalid parse_mode")
+
+// ErrInvalidChatAction is returned when a chat action is not one of the ChatAction constants
+var ErrInvalidChatAction = errors.New("invalid chat action")
+
+// ErrInvalidWebAppURL is returned when a web_app button URL is not an absolute https URL
+var ErrInvalidWebAppURL = errors.New("web_app url must be an absolute https url")
+
+// ErrCallbackDataNotFound is returned when callback data of a button is not stored or expired
+var ErrCallbackDataNotFound = errors.New("callback data not found")
+
+// ErrUnsupportedStream is returned by ExecuteAction for streams other than tg_direct
+var ErrUnsupportedStream = errors.New("unsupported action stream")
+
+// ErrUnsupportedContentType is returned by ExecuteAction for unknown content types without an attachment
+var ErrUnsupportedContentType = errors.New("unsupported action content type")
+
+// ErrInvalidActionPayload is returned by ExecuteAction when the attachment doesn't match the content type
+var ErrInvalidActionPayload = errors.New("invalid action payload")
+
+// ErrCallbackAlertTooLong is returned when show_alert text exceeds MaxCallbackAnswerLength
+// Toast texts are truncated instead
+var ErrCallbackAlertTooLong = errors.New("callback alert text is too long")
+
+// ErrCaptionTooLong is returned when a formatted caption exceeds MaxCaptionLength
+// Plain captions are truncated instead
+var ErrCaptionTooLong = errors.New("caption is too long")
+
+// ErrCallbackURLNotAllowed is returned when a callback answer url is neither a t.me link
+// nor sent with AnswerGameCallback
+var ErrCallbackURLNotAllowed = errors.New("callback answer url is allowed only for games and t.me links")
+
+// ErrDuplicateMessage is returned by SendMessageOnce when the message was already sent
+var ErrDuplicateMessage = errors.New("message with this dedupe key was already sent")
 
 // APIError represents Telegram API error
 type APIError struct {
 	Code        int
 	Description string
+	RetryAfter  int // Seconds to wait before retrying, set for 429 errors
 }
 
 func (e *APIError) Error() string {
@@ -60,6 +105,67 @@ func IsForbiddenError(err error) bool {
 	return false
 }
 
+// IsBotBlockedError checks if error is forbidden (403) because the user blocked the bot
+// The user may unblock the bot later, unlike IsDeactivatedError
+func IsBotBlockedError(err error) bool {
+	return isForbiddenWith(err, "bot was blocked by the user")
+}
+
+// IsDeactivatedError checks if error is forbidden (403) because the user account is deleted
+// Such a chat will never accept messages again
+func IsDeactivatedError(err error) bool {
+	return isForbiddenWith(err, "user is deactivated")
+}
+
+// isForbiddenWith checks if error is forbidden (403) with the phrase in its description
+func isForbiddenWith(err error, phrase string) bool {
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == 403 {
+		return strings.Contains(strings.ToLower(apiErr.Description), phrase)
+	}
+	return false
+}
+
+// IsMessageNotFoundError checks if error is bad request (400) about a missing message,
+// e.g. "message to pin not found" or "message to delete not found"
+func IsMessageNotFoundError(err error) bool {
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == 400 {
+		description := strings.ToLower(apiErr.Description)
+		return strings.Contains(description, "message") && strings.Contains(description, "not found")
+	}
+	return false
+}
+
+// IsNotModifiedError checks if error is bad request (400) about an edit that doesn't change the message
+// Edit methods treat it as success with the ignore_not_modified option
+func IsNotModifiedError(err error) bool {
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == 400 {
+		return strings.Contains(strings.ToLower(apiErr.Description), "message is not modified")
+	}
+	return false
+}
+
+// badMediaDescriptions are parts of 400 descriptions returned for unusable media
+var badMediaDescriptions = []string{
+	"wrong file identifier",
+	"wrong remote file identifier",
+	"failed to get http url content",
+	"wrong type of the web page content",
+}
+
+// IsBadMediaError checks if error is bad request (400) about media that Telegram can't use:
+// an unreachable URL or a file_id of another bot. Such media can be downloaded and uploaded instead
+func IsBadMediaError(err error) bool {
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == 400 {
+		description := strings.ToLower(apiErr.Description)
+		for _, bad := range badMediaDescriptions {
+			if strings.Contains(description, bad) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetErrorCode returns error code if it's APIError, otherwise -1
 func GetErrorCode(err error) int {
 	if apiErr, ok := err.(*APIError); ok {
@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"fmt"
+	"io"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// FileSource describes where the content of an outgoing file comes from
+// Exactly one of URL, Path, Bytes or Reader is expected to be set
+type FileSource struct {
+	URL    string    // Remote file URL or file_id
+	Path   string    // Local file path
+	Name   string    // File name for Bytes and Reader uploads
+	Bytes  []byte    // In-memory file content
+	Reader io.Reader // Streamed file content
+}
+
+// FileURL creates a FileSource from a remote URL or file_id
+func FileURL(url string) FileSource {
+	return FileSource{URL: url}
+}
+
+// FilePath creates a FileSource that uploads a local file
+func FilePath(path string) FileSource {
+	return FileSource{Path: path}
+}
+
+// FileBytes creates a FileSource that uploads in-memory data under the given file name
+func FileBytes(name string, data []byte) FileSource {
+	return FileSource{Name: name, Bytes: data}
+}
+
+// FileReader creates a FileSource that streams data from r under the given file name
+func FileReader(name string, r io.Reader) FileSource {
+	return FileSource{Name: name, Reader: r}
+}
+
+// requestFileData converts FileSource to tgbotapi file data
+func (f FileSource) requestFileData() tgbotapi.RequestFileData {
+	switch {
+	case f.Reader != nil:
+		return tgbotapi.FileReader{Name: f.Name, Reader: f.Reader}
+	case f.Bytes != nil:
+		return tgbotapi.FileBytes{Name: f.Name, Bytes: f.Bytes}
+	case f.Path != "":
+		return tgbotapi.FilePath(f.Path)
+	default:
+		return tgbotapi.FileURL(f.URL)
+	}
+}
+
+// InputMedia represents media content used to replace media of an existing message
+type InputMedia struct {
+	Type      string     // photo, video, animation, audio, document
+	Media     FileSource // Media file
+	Caption   string     // Optional caption
+	ParseMode string     // Optional caption parse mode
+}
+
+// inputMedia converts InputMedia to the matching tgbotapi InputMedia type
+func (m InputMedia) inputMedia() (interface{}, error) {
+	base := tgbotapi.BaseInputMedia{
+		Type:      m.Type,
+		Media:     m.Media.requestFileData(),
+		Caption:   m.Caption,
+		ParseMode: m.ParseMode,
+	}
+
+	switch m.Type {
+	case "photo":
+		return tgbotapi.InputMediaPhoto{BaseInputMedia: base}, nil
+	case "video":
+		return tgbotapi.InputMediaVideo{BaseInputMedia: base}, nil
+	case "animation":
+		return tgbotapi.InputMediaAnimation{BaseInputMedia: base}, nil
+	case "audio":
+		return tgbotapi.InputMediaAudio{BaseInputMedia: base}, nil
+	case "document":
+		return tgbotapi.InputMediaDocument{BaseInputMedia: base}, nil
+	default:
+		return nil, fmt.Errorf("unsupported input media type: %q", m.Type)
+	}
+}
+
+// MediaGroupItem represents one media item of an album sent by SendMediaGroup
+type MediaGroupItem struct {
+	Type      string     // photo, video, audio, document
+	Media     FileSource // Media file
+	Caption   string     // Optional caption
+	ParseMode string     // Optional caption parse mode
+
+	// RawExtra holds extra InputMedia fields merged into the request as is
+	// It allows using InputMedia fields not supported by the library yet,
+	// "type" and "media" keys are ignored
+	RawExtra map[string]interface{}
+}
+
+// inputMedia builds the InputMedia object of the item at position idx
+// Returns a file to upload if the media is not a URL or file_id
+func (item MediaGroupItem) inputMedia(idx int) (map[string]interface{}, *tgbotapi.RequestFile, error) {
+	media := make(map[string]interface{}, len(item.RawExtra)+4)
+	for k, v := range item.RawExtra {
+		media[k] = v
+	}
+
+	media["type"] = item.Type
+	if item.ParseMode != "" {
+		media["parse_mode"] = item.ParseMode
+	}
+	if item.Caption != "" {
+		parseMode, _ := media["parse_mode"].(string)
+		caption, err := fitCaption(item.Caption, parseMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("media group item #%d: %w", idx, err)
+		}
+		media["caption"] = caption
+	}
+
+	data := item.Media.requestFileData()
+	if !data.NeedsUpload() {
+		media["media"] = data.SendData()
+		return media, nil, nil
+	}
+
+	name := fmt.Sprintf("file-%d", idx)
+	media["media"] = "attach://" + name
+	return media, &tgbotapi.RequestFile{Name: name, Data: data}, nil
+}
@@ -1,7 +1,17 @@
 package telegram
 
 import (
+	"fmt"
+	"html"
 	"strings"
+	"unicode"
+)
+
+// Length limits of Telegram in UTF-16 code units
+const (
+	MaxMessageLength        = 4096
+	MaxCaptionLength        = 1024
+	MaxCallbackAnswerLength = 200
 )
 
 // ParseMode constants for Telegram message formatting
@@ -11,17 +21,28 @@ const (
 	ParseModeHTML       = "HTML"
 )
 
+// validateParseMode checks parse_mode before sending, empty mode means no formatting
+func validateParseMode(mode string) error {
+	switch mode {
+	case "", ParseModeMarkdown, ParseModeMarkdownV2, ParseModeHTML:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidParseMode, mode)
+}
+
 // EscapeMarkdownV2 escapes special characters for MarkdownV2 parse mode
-// Characters that need escaping: _ * [ ] ( ) ~ ` > # + - = | { } . !
+// Characters that need escaping: _ * [ ] ( ) ~ ` > # + - = | { } . ! and backslash itself
+// Text is scanned once, so every character is escaped exactly once
 func EscapeMarkdownV2(text string) string {
-	// Characters that must be escaped in MarkdownV2
-	specialChars := []string{"_", "*", "[", "]", "(", ")", "~", "`", ">", "#", "+", "-", "=", "|", "{", "}", ".", "!"}
-
-	result := text
-	for _, char := range specialChars {
-		result = strings.ReplaceAll(result, char, "\\"+char)
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if isMarkdownV2Special(r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
 	}
-	return result
+	return b.String()
 }
 
 // EscapeHTML escapes special characters for HTML parse mode
@@ -199,7 +220,8 @@ func formatInt64(n int64) string {
 
 // FormatMarkdownV2 processes text with markdown formatting
 // Supports: *bold*, _italic_, `code`, ```pre```, [link](url), ~strikethrough~, __underline__, ||spoiler||
-// Escapes special characters outside of formatting blocks
+// Formatting blocks may be nested, their content is formatted recursively
+// Escapes special characters that are not part of formatting
 func FormatMarkdownV2(text string) string {
 	if text == "" {
 		return ""
@@ -234,8 +256,7 @@ func FormatMarkdownV2(text string) string {
 		if i+1 < len(runes) && runes[i] == '|' && runes[i+1] == '|' {
 			end := findClosingDouble(runes, i+2, '|')
 			if end != -1 {
-				// Content inside spoiler needs escaping
-				content := escapeInsideFormat(string(runes[i+2 : end]))
+				content := FormatMarkdownV2(string(runes[i+2 : end]))
 				result.WriteString("||")
 				result.WriteString(content)
 				result.WriteString("||")
@@ -248,7 +269,7 @@ func FormatMarkdownV2(text string) string {
 		if i+1 < len(runes) && runes[i] == '_' && runes[i+1] == '_' {
 			end := findClosingDouble(runes, i+2, '_')
 			if end != -1 {
-				content := escapeInsideFormat(string(runes[i+2 : end]))
+				content := FormatMarkdownV2(string(runes[i+2 : end]))
 				result.WriteString("__")
 				result.WriteString(content)
 				result.WriteString("__")
@@ -261,7 +282,7 @@ func FormatMarkdownV2(text string) string {
 		if runes[i] == '*' {
 			end := findClosingChar(runes, i+1, '*')
 			if end != -1 {
-				content := escapeInsideFormat(string(runes[i+1 : end]))
+				content := FormatMarkdownV2(string(runes[i+1 : end]))
 				result.WriteRune('*')
 				result.WriteString(content)
 				result.WriteRune('*')
@@ -274,7 +295,7 @@ func FormatMarkdownV2(text string) string {
 		if runes[i] == '_' && (i+1 >= len(runes) || runes[i+1] != '_') {
 			end := findClosingChar(runes, i+1, '_')
 			if end != -1 && (end+1 >= len(runes) || runes[end+1] != '_') {
-				content := escapeInsideFormat(string(runes[i+1 : end]))
+				content := FormatMarkdownV2(string(runes[i+1 : end]))
 				result.WriteRune('_')
 				result.WriteString(content)
 				result.WriteRune('_')
@@ -287,7 +308,7 @@ func FormatMarkdownV2(text string) string {
 		if runes[i] == '~' {
 			end := findClosingChar(runes, i+1, '~')
 			if end != -1 {
-				content := escapeInsideFormat(string(runes[i+1 : end]))
+				content := FormatMarkdownV2(string(runes[i+1 : end]))
 				result.WriteRune('~')
 				result.WriteString(content)
 				result.WriteRune('~')
@@ -298,9 +319,12 @@ func FormatMarkdownV2(text string) string {
 
 		// Check for link [text](url)
 		if runes[i] == '[' {
-			linkEnd := parseLinkMarkdown(runes, i)
+			textEnd, linkEnd := parseLinkMarkdown(runes, i)
 			if linkEnd != -1 {
-				result.WriteString(string(runes[i : linkEnd+1]))
+				// Visible text is kept as is, the URL gets its own escaping
+				result.WriteString(string(runes[i : textEnd+2]))
+				result.WriteString(escapeLinkURL(runes[textEnd+2 : linkEnd]))
+				result.WriteRune(')')
 				i = linkEnd + 1
 				continue
 			}
@@ -355,10 +379,11 @@ func findClosingDouble(runes []rune, start int, char rune) int {
 	return -1
 }
 
-// parseLinkMarkdown parses [text](url) and returns end index
-func parseLinkMarkdown(runes []rune, start int) int {
+// parseLinkMarkdown parses [text](url) and returns indexes of the closing ] and )
+// Returns -1 as the end index if there is no link at start
+func parseLinkMarkdown(runes []rune, start int) (textEnd, end int) {
 	if runes[start] != '[' {
-		return -1
+		return -1, -1
 	}
 
 	// Find ]
@@ -374,41 +399,81 @@ func parseLinkMarkdown(runes []rune, start int) int {
 	}
 
 	if bracketEnd == -1 || bracketEnd+1 >= len(runes) || runes[bracketEnd+1] != '(' {
-		return -1
+		return -1, -1
 	}
 
-	// Find )
+	// Find ), parentheses inside the URL are balanced. An escaped \) closes an
+	// inner ( but never the link
 	parenEnd := -1
 	depth := 1
 	for i := bracketEnd + 2; i < len(runes); i++ {
-		if runes[i] == '(' {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				if runes[i+1] == ')' && depth > 1 {
+					depth--
+				}
+				i++
+			}
+		case '(':
 			depth++
-		} else if runes[i] == ')' {
+		case ')':
 			depth--
-			if depth == 0 {
-				parenEnd = i
-				break
+			if depth > 0 {
+				continue
+			}
+			// An unbalanced ) followed by more URL characters, as in https://x.com/a)b),
+			// belongs to the URL when the link is still closed before whitespace
+			if continuesLinkURL(runes, i+1) {
+				depth = 1
+				continue
 			}
+			parenEnd = i
 		}
-		if runes[i] == '\\' && i+1 < len(runes) {
-			i++
+		if parenEnd != -1 {
+			break
 		}
 	}
 
-	return parenEnd
+	return bracketEnd, parenEnd
 }
 
-// escapeInsideFormat escapes special chars inside formatting blocks
-// Does not escape the formatting character itself
-func escapeInsideFormat(text string) string {
-	// Inside formatted text, we need to escape: ) ( ` \ and >
-	// but NOT the formatting chars themselves
-	specialInside := []string{"\\", "`", ")", "(", ">"}
-	result := text
-	for _, char := range specialInside {
-		result = strings.ReplaceAll(result, char, "\\"+char)
+// continuesLinkURL reports whether the URL goes on after an unbalanced ) at start-1
+// It does when a URL character follows and another ) comes before whitespace,
+// a ) right after it closes outer text like "([docs](https://x.com))"
+func continuesLinkURL(runes []rune, start int) bool {
+	if start >= len(runes) || runes[start] == ')' || unicode.IsSpace(runes[start]) {
+		return false
 	}
-	return result
+	for i := start; i < len(runes); i++ {
+		switch {
+		case unicode.IsSpace(runes[i]) || runes[i] == '[':
+			return false
+		case runes[i] == ')':
+			return true
+		}
+	}
+	return false
+}
+
+// escapeLinkURL escapes the URL part of a MarkdownV2 link
+// Inside (...) only ) and \ have to be escaped, already escaped ones are kept
+func escapeLinkURL(url []rune) string {
+	var b strings.Builder
+	for i := 0; i < len(url); i++ {
+		r := url[i]
+		if r == '\\' && i+1 < len(url) && (url[i+1] == ')' || url[i+1] == '\\') {
+			b.WriteRune(r)
+			b.WriteRune(url[i+1])
+			i++
+			continue
+		}
+		if r == ')' || r == '\\' {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
 
 // isMarkdownV2Special checks if rune is a special MarkdownV2 character
@@ -420,20 +485,89 @@ func isMarkdownV2Special(r rune) bool {
 	return false
 }
 
-// StripMarkdown removes all markdown formatting from text
+// StripMarkdown removes MarkdownV2 formatting from text
+// Escaped characters are unescaped, formatting delimiters are dropped,
+// links keep only their visible text and code keeps its content
 func StripMarkdown(text string) string {
-	// Remove code blocks
-	result := text
+	var result strings.Builder
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			result.WriteRune(runes[i])
+
+		case r == '`' && i+2 < len(runes) && runes[i+1] == '`' && runes[i+2] == '`':
+			end := findClosingCodeBlock(runes, i+3)
+			if end == -1 {
+				i += 2
+				continue
+			}
+			code := stripCode(stripCodeLanguage(runes[i+3 : end]))
+			result.WriteString(strings.TrimSuffix(code, "\n"))
+			i = end + 2
 
-	// Simple removal of formatting characters
-	for _, char := range []string{"```", "||", "__", "*", "_", "~", "`"} {
-		result = strings.ReplaceAll(result, char, "")
+		case r == '`':
+			end := findClosingChar(runes, i+1, '`')
+			if end == -1 {
+				continue
+			}
+			result.WriteString(stripCode(runes[i+1 : end]))
+			i = end
+
+		case r == '[' || (r == '!' && i+1 < len(runes) && runes[i+1] == '['):
+			// Links and custom emoji ![👍](tg://emoji?id=...) keep only the visible text
+			start := i
+			if r == '!' {
+				start++
+			}
+			textEnd, end := parseLinkMarkdown(runes, start)
+			if end == -1 {
+				result.WriteRune(r)
+				continue
+			}
+			result.WriteString(StripMarkdown(string(runes[start+1 : textEnd])))
+			i = end
+
+		case r == '*' || r == '_' || r == '~':
+			// Formatting delimiter
+
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			i++
+
+		default:
+			result.WriteRune(r)
+		}
 	}
 
-	// Remove escape characters
-	result = strings.ReplaceAll(result, "\\", "")
+	return result.String()
+}
+
+// stripCodeLanguage drops the language line of a ```lang code block
+func stripCodeLanguage(code []rune) []rune {
+	for i, r := range code {
+		if r == '\n' {
+			return code[i+1:]
+		}
+		if unicode.IsSpace(r) {
+			break
+		}
+	}
+	return code
+}
 
-	return result
+// stripCode unescapes ` and \ inside code, the only escapes allowed there
+func stripCode(code []rune) string {
+	var b strings.Builder
+	for i := 0; i < len(code); i++ {
+		if code[i] == '\\' && i+1 < len(code) && (code[i+1] == '`' || code[i+1] == '\\') {
+			i++
+		}
+		b.WriteRune(code[i])
+	}
+	return b.String()
 }
 
 // TruncateText truncates text to maxLen, adding "..." if truncated
@@ -448,3 +582,227 @@ func TruncateText(text string, maxLen int) string {
 	}
 	return string(runes[:maxLen-3]) + "..."
 }
+
+// TruncateUTF16 truncates text to maxUnits UTF-16 code units, adding "..." if truncated
+// Telegram counts message and caption limits in UTF-16, where emoji take 2 units.
+// Surrogate pairs are never split
+func TruncateUTF16(text string, maxUnits int) string {
+	if maxUnits <= 0 {
+		return ""
+	}
+	if utf16Len(text) <= maxUnits {
+		return text
+	}
+
+	suffix := ""
+	if maxUnits > 3 {
+		suffix = "..."
+		maxUnits -= 3
+	}
+
+	units := 0
+	for i, r := range text {
+		n := 1
+		if r >= 0x10000 {
+			n = 2 // Encoded as a surrogate pair
+		}
+		if units+n > maxUnits {
+			return text[:i] + suffix
+		}
+		units += n
+	}
+	return text
+}
+
+// fitCaption fits a caption into MaxCaptionLength
+// A plain caption is truncated. A formatted one can't be cut without breaking its
+// markup, so ErrCaptionTooLong is returned if its parsed text doesn't fit
+func fitCaption(caption, parseMode string) (string, error) {
+	if utf16Len(caption) <= MaxCaptionLength {
+		return caption, nil
+	}
+	if parseMode == "" {
+		return TruncateUTF16(caption, MaxCaptionLength), nil
+	}
+	if n := utf16Len(parsedText(caption, parseMode)); n > MaxCaptionLength {
+		return "", fmt.Errorf("%w: %d characters, max is %d", ErrCaptionTooLong, n, MaxCaptionLength)
+	}
+	return caption, nil
+}
+
+// parsedText approximates the text Telegram shows for text in the parse mode
+func parsedText(text, parseMode string) string {
+	if parseMode != ParseModeHTML {
+		return StripMarkdown(text)
+	}
+	var b strings.Builder
+	for _, tok := range tokenizeHTML(text) {
+		if tok.name == "" {
+			b.WriteString(tok.text)
+		}
+	}
+	return html.UnescapeString(b.String())
+}
+
+// htmlTags are the tags supported by Telegram in HTML parse mode
+var htmlTags = map[string]bool{
+	"b": true, "strong": true, "i": true, "em": true, "u": true, "ins": true,
+	"s": true, "strike": true, "del": true, "span": true, "tg-spoiler": true,
+	"a": true, "code": true, "pre": true, "blockquote": true, "tg-emoji": true,
+}
+
+// htmlToken is a piece of HTML text, either a tag or plain text
+type htmlToken struct {
+	text    string
+	name    string // Tag name, empty for plain text
+	closing bool
+}
+
+// SanitizeHTML makes text safe for HTML parse mode
+// Supported tags are kept when they are balanced, any other "<", ">" and "&"
+// that don't form a tag or an entity are escaped, so stray characters
+// in user text don't break the message
+func SanitizeHTML(text string) string {
+	tokens := tokenizeHTML(text)
+
+	// Match closing tags with opening ones, unmatched tags become plain text
+	var stack []int
+	for i, tok := range tokens {
+		if tok.name == "" {
+			continue
+		}
+		if !tok.closing {
+			stack = append(stack, i)
+			continue
+		}
+		if len(stack) > 0 && tokens[stack[len(stack)-1]].name == tok.name {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		tokens[i].name = ""
+	}
+	for _, i := range stack {
+		tokens[i].name = ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, tok := range tokens {
+		if tok.name != "" {
+			b.WriteString(tok.text)
+		} else {
+			b.WriteString(escapeHTMLText(tok.text))
+		}
+	}
+	return b.String()
+}
+
+// tokenizeHTML splits text into supported tags and plain text
+func tokenizeHTML(text string) []htmlToken {
+	var tokens []htmlToken
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '<' {
+			continue
+		}
+		end := strings.IndexAny(text[i+1:], "<>")
+		if end < 0 || text[i+1+end] != '>' {
+			continue
+		}
+		tag := text[i : i+end+2]
+		name, closing := parseHTMLTag(tag)
+		if !htmlTags[name] {
+			continue
+		}
+
+		if start < i {
+			tokens = append(tokens, htmlToken{text: text[start:i]})
+		}
+		tokens = append(tokens, htmlToken{text: tag, name: name, closing: closing})
+		i += end + 1
+		start = i + 1
+	}
+	if start < len(text) {
+		tokens = append(tokens, htmlToken{text: text[start:]})
+	}
+	return tokens
+}
+
+// parseHTMLTag returns the lowercase name of a tag like <a href="..."> or </a>
+func parseHTMLTag(tag string) (name string, closing bool) {
+	inner := tag[1 : len(tag)-1]
+	if strings.HasPrefix(inner, "/") {
+		closing = true
+		inner = inner[1:]
+	}
+	end := strings.IndexFunc(inner, unicode.IsSpace)
+	if end < 0 {
+		end = len(inner)
+	} else if closing {
+		return "", false // Closing tags can't have attributes
+	}
+	return strings.ToLower(inner[:end]), closing
+}
+
+// escapeHTMLText escapes "<", ">" and "&" unless "&" starts an entity
+func escapeHTMLText(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '&':
+			if isHTMLEntity(text[i:]) {
+				b.WriteByte('&')
+			} else {
+				b.WriteString("&amp;")
+			}
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+	return b.String()
+}
+
+// isHTMLEntity reports whether text starts with an entity supported by Telegram
+func isHTMLEntity(text string) bool {
+	for _, entity := range []string{"&lt;", "&gt;", "&amp;", "&quot;"} {
+		if strings.HasPrefix(text, entity) {
+			return true
+		}
+	}
+
+	if !strings.HasPrefix(text, "&#") {
+		return false
+	}
+	digits := text[2:]
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	if strings.HasPrefix(digits, "x") || strings.HasPrefix(digits, "X") {
+		digits = digits[1:]
+		isDigit = func(c byte) bool {
+			return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+		}
+	}
+	n := 0
+	for n < len(digits) && isDigit(digits[n]) {
+		n++
+	}
+	return n > 0 && n < len(digits) && digits[n] == ';'
+}
+
+// formatText prepares text for the parse mode
+// MarkdownV2 text goes through FormatMarkdownV2 and HTML through SanitizeHTML.
+// Legacy Markdown and plain text are sent as is
+func formatText(text, parseMode string) string {
+	switch parseMode {
+	case ParseModeMarkdownV2:
+		return FormatMarkdownV2(text)
+	case ParseModeHTML:
+		return SanitizeHTML(text)
+	default:
+		return text
+	}
+}
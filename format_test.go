@@ -0,0 +1,154 @@
+package telegram
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatMarkdownV2LinkURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "[a](https://x.com)", "[a](https://x.com)"},
+		{"escaped paren", `[a](https://x.com/a\)b)`, `[a](https://x.com/a\)b)`},
+		{"backslash", `[a](https://x.com/a\b)`, `[a](https://x.com/a\\b)`},
+		{"escaped backslash", `[a](https://x.com/a\\b)`, `[a](https://x.com/a\\b)`},
+		{"query with parens", `[a](https://x.com/?utm=(a\))`, `[a](https://x.com/?utm=(a\))`},
+		{"unescaped paren ends url", "[a](https://x.com/a)b", `[a](https://x.com/a)b`},
+		{"inside parens", "(see [a](http://a.com)) ok", `\(see [a](http://a.com)\) ok`},
+		{"wrapped in parens", "([docs](https://x.com))", `\([docs](https://x.com)\)`},
+		{"unclosed", "[a](https://x.com", `\[a\]\(https://x\.com`},
+		{"raw query with parens", "[a](https://x.com/?utm=(a))", `[a](https://x.com/?utm=(a\))`},
+		{"raw nested parens", "[a](https://x.com/f(a(b))c) ok", `[a](https://x.com/f(a(b\)\)c) ok`},
+		{"raw unbalanced paren", "[a](https://x.com/a)b)", `[a](https://x.com/a\)b)`},
+		{"raw backslash and paren", `[a](https://x.com/a\b(c))`, `[a](https://x.com/a\\b(c\))`},
+		{"raw paren then text", "[a](https://x.com/a) b)", `[a](https://x.com/a) b\)`},
+		{"raw parens in parens", "(see [a](https://x.com/?q=(1)))", `\(see [a](https://x.com/?q=(1\))\)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatMarkdownV2(tt.in); got != tt.want {
+				t.Errorf("FormatMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"plain", "hello world", "hello world"},
+		{"underscore and backslash", `a_b\c`, `a\_b\\c`},
+		{"all specials", "_*[]()~`>#+-=|{}.!", "\\_\\*\\[\\]\\(\\)\\~\\`\\>\\#\\+\\-\\=\\|\\{\\}\\.\\!"},
+		{"double backslash", `\\`, `\\\\`},
+		{"pre-escaped star", `\*`, `\\\*`},
+		{"trailing backslash", `end\`, `end\\`},
+		{"dash after escape", `a\-b`, `a\\\-b`},
+		{"repeated", "1.2.3", `1\.2\.3`},
+		{"unicode", "привет, 👋!", `привет, 👋\!`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMarkdownV2(tt.in); got != tt.want {
+				t.Errorf("EscapeMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMarkdownV2Nesting(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bold italic", "*bold _and italic_*", "*bold _and italic_*"},
+		{"bold link", "*[link](https://x.com)*", "*[link](https://x.com)*"},
+		{"siblings", "*Important:* see _this_", "*Important:* see _this_"},
+		{"specials inside", "*v1.5 is out!*", `*v1\.5 is out\!*`},
+		{"three levels", "*a _b ~c~ b_ a*", "*a _b ~c~ b_ a*"},
+		{"spoiler bold italic", "||*bold _it_*||", "||*bold _it_*||"},
+		{"underline strike link", "__u ~s [l](https://x.com/a\\)b) s~ u__", "__u ~s [l](https://x.com/a\\)b) s~ u__"},
+		{"unbalanced inner", "*bold _unclosed*", `*bold \_unclosed*`},
+		{"unbalanced third level", "*a _b ~c_ d*", `*a _b \~c_ d*`},
+		{"unbalanced outer", "*a _b_", `\*a _b_`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatMarkdownV2(tt.in); got != tt.want {
+				t.Errorf("FormatMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"escaped specials", `\*literal\* 1\.5\!`, "*literal* 1.5!"},
+		{"escaped backslash", `a\\b`, `a\b`},
+		{"trailing backslash", `a\`, `a\`},
+		{"delimiters", "*bold* _it_ ~s~ __u__ ||sp||", "bold it s u sp"},
+		{"link", "see [text](https://x.com/a\\)b) now", "see text now"},
+		{"formatted link text", "[*bold* \\.](https://x.com)", "bold ."},
+		{"custom emoji", "![👍](tg://emoji?id=5368324170671202286)", "👍"},
+		{"unclosed link", "[text", "[text"},
+		{"inline code", "run `a\\`b *c*`", "run a`b *c*"},
+		{"pre", "```\ncode *x* \\\\\n```", "code *x* \\"},
+		{"pre with language", "```go\nfmt.Println()\n```", "fmt.Println()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripMarkdown(tt.in); got != tt.want {
+				t.Errorf("StripMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitCaption(t *testing.T) {
+	long := strings.Repeat("a", MaxCaptionLength+10)
+	wrapped := "*" + strings.Repeat("a", MaxCaptionLength-2) + "*"
+
+	tests := []struct {
+		name      string
+		caption   string
+		parseMode string
+		want      string
+		wantErr   bool
+	}{
+		{"short", "hello", ParseModeMarkdownV2, "hello", false},
+		{"plain is truncated", long, "", long[:MaxCaptionLength-3] + "...", false},
+		{"markdown fits once parsed", strings.Repeat(`\.`, MaxCaptionLength/2+10), ParseModeMarkdownV2, strings.Repeat(`\.`, MaxCaptionLength/2+10), false},
+		{"markdown too long", wrapped + "bbb", ParseModeMarkdownV2, "", true},
+		{"html fits once parsed", "<b>" + strings.Repeat("&amp;", 300) + "</b>", ParseModeHTML, "<b>" + strings.Repeat("&amp;", 300) + "</b>", false},
+		{"html too long", "<b>" + long + "</b>", ParseModeHTML, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fitCaption(tt.caption, tt.parseMode)
+			if tt.wantErr {
+				if !errors.Is(err, ErrCaptionTooLong) {
+					t.Fatalf("fitCaption() error = %v, want ErrCaptionTooLong", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fitCaption() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fitCaption() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
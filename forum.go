@@ -0,0 +1,135 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CreateForumTopic creates a topic in a forum supergroup
+// Supported options: icon_color (one of the RGB colors allowed by Telegram), icon_custom_emoji_id
+func (c *Client) CreateForumTopic(ctx context.Context, chatID int64, name string, opts map[string]interface{}) (*ForumTopic, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params["name"] = name
+	if color, ok := asInt(opts["icon_color"]); ok {
+		params.AddNonZero("icon_color", color)
+	}
+	if emojiID, ok := opts["icon_custom_emoji_id"].(string); ok {
+		params.AddNonEmpty("icon_custom_emoji_id", emojiID)
+	}
+
+	resp, err := c.botFor(ctx).MakeRequest("createForumTopic", params)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	var topic ForumTopic
+	if err := json.Unmarshal(resp.Result, &topic); err != nil {
+		return nil, fmt.Errorf("failed to decode forum topic: %w", err)
+	}
+	return &topic, nil
+}
+
+// EditForumTopic changes name and icon of a forum topic
+// Supported options: name, icon_custom_emoji_id (empty string removes the icon)
+func (c *Client) EditForumTopic(ctx context.Context, chatID, messageThreadID int64, opts map[string]interface{}) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero64("message_thread_id", messageThreadID)
+	if name, ok := opts["name"].(string); ok {
+		params.AddNonEmpty("name", name)
+	}
+	if emojiID, ok := opts["icon_custom_emoji_id"].(string); ok {
+		params["icon_custom_emoji_id"] = emojiID
+	}
+
+	_, err := c.botFor(ctx).MakeRequest("editForumTopic", params)
+	return c.wrapError(err)
+}
+
+// CloseForumTopic closes an open forum topic
+func (c *Client) CloseForumTopic(ctx context.Context, chatID, messageThreadID int64) error {
+	return c.forumTopicRequest(ctx, "closeForumTopic", chatID, messageThreadID)
+}
+
+// ReopenForumTopic reopens a closed forum topic
+func (c *Client) ReopenForumTopic(ctx context.Context, chatID, messageThreadID int64) error {
+	return c.forumTopicRequest(ctx, "reopenForumTopic", chatID, messageThreadID)
+}
+
+// DeleteForumTopic deletes a forum topic along with all its messages
+func (c *Client) DeleteForumTopic(ctx context.Context, chatID, messageThreadID int64) error {
+	return c.forumTopicRequest(ctx, "deleteForumTopic", chatID, messageThreadID)
+}
+
+// forumTopicRequest calls a method that takes only chat_id and message_thread_id
+func (c *Client) forumTopicRequest(ctx context.Context, method string, chatID, messageThreadID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero64("message_thread_id", messageThreadID)
+
+	_, err := c.botFor(ctx).MakeRequest(method, params)
+	return c.wrapError(err)
+}
+
+// EditGeneralForumTopic renames the General topic of a forum supergroup
+func (c *Client) EditGeneralForumTopic(ctx context.Context, chatID int64, name string) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params["name"] = name
+
+	_, err := c.botFor(ctx).MakeRequest("editGeneralForumTopic", params)
+	return c.wrapError(err)
+}
+
+// CloseGeneralForumTopic closes the open General topic
+func (c *Client) CloseGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return c.generalForumTopicRequest(ctx, "closeGeneralForumTopic", chatID)
+}
+
+// ReopenGeneralForumTopic reopens the closed General topic, it is unhidden if it was hidden
+func (c *Client) ReopenGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return c.generalForumTopicRequest(ctx, "reopenGeneralForumTopic", chatID)
+}
+
+// HideGeneralForumTopic hides the General topic, it is closed if it was open
+func (c *Client) HideGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return c.generalForumTopicRequest(ctx, "hideGeneralForumTopic", chatID)
+}
+
+// UnhideGeneralForumTopic unhides the General topic
+func (c *Client) UnhideGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return c.generalForumTopicRequest(ctx, "unhideGeneralForumTopic", chatID)
+}
+
+// generalForumTopicRequest calls a General topic method that takes only chat_id
+func (c *Client) generalForumTopicRequest(ctx context.Context, method string, chatID int64) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", chatID)
+
+	_, err := c.botFor(ctx).MakeRequest(method, params)
+	return c.wrapError(err)
+}
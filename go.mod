@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
 	go.uber.org/zap v1.27.0
+	golang.org/x/time v0.5.0
 )
 
 require go.uber.org/multierr v1.11.0 // indirect
@@ -0,0 +1,174 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// RequestHook wraps every Bot API request
+// method is the API method name, e.g. "sendMessage". next performs the request,
+// a hook may return an error without calling next to short-circuit the request
+type RequestHook func(ctx context.Context, method string, next func() error) error
+
+// Stats receives metrics of Bot API requests
+// code is the HTTP status of the response, which matches the Telegram error code,
+// or 0 if the request failed without a response
+type Stats interface {
+	IncSend(method string, code int)
+	ObserveLatency(method string, d time.Duration)
+}
+
+// WithStats sets a Stats implementation that observes every Bot API request
+func WithStats(s Stats) Option {
+	return func(c *Client) {
+		c.stats = s
+	}
+}
+
+// errRequestSkipped is returned when a request hook returns nil without calling next
+var errRequestSkipped = errors.New("request was skipped by the request hook")
+
+// WithRequestHook sets a hook that wraps every Bot API request, e.g. for tracing or metrics
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) {
+		c.requestHook = hook
+	}
+}
+
+// requestTimeoutKey is the context key of a per-call request timeout
+type requestTimeoutKey struct{}
+
+// WithCallTimeout returns a context whose Bot API requests use timeout instead of
+// the client default set by WithRequestTimeout. Every request of the call, including
+// retries, gets its own deadline. The HTTP client timeout of WithTimeout still applies
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// callContext applies the timeout option of send and edit methods, a time.Duration
+func callContext(ctx context.Context, opts map[string]interface{}) context.Context {
+	if timeout, ok := opts["timeout"].(time.Duration); ok && timeout > 0 {
+		return WithCallTimeout(ctx, timeout)
+	}
+	return ctx
+}
+
+// contextClient binds Bot API requests to the caller's context and runs the request hook
+// tgbotapi makes requests without a context, so it is attached here
+type contextClient struct {
+	base    tgbotapi.HTTPClient
+	ctx     context.Context
+	timeout time.Duration // Default deadline of a request, see WithRequestTimeout
+	hook    RequestHook
+	stats   Stats
+}
+
+// Do implements tgbotapi.HTTPClient
+func (cc contextClient) Do(req *http.Request) (*http.Response, error) {
+	timeout := cc.timeout
+	if callTimeout, ok := cc.ctx.Value(requestTimeoutKey{}).(time.Duration); ok && callTimeout > 0 {
+		timeout = callTimeout
+	}
+	if timeout <= 0 {
+		return cc.doWithContext(cc.ctx, req)
+	}
+
+	// The deadline has to outlive Do, since tgbotapi reads the body afterwards
+	ctx, cancel := context.WithTimeout(cc.ctx, timeout)
+	resp, err := cc.doWithContext(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// doWithContext runs the request bound to ctx through the request hook
+func (cc contextClient) doWithContext(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	method := path.Base(req.URL.Path)
+	if cc.hook == nil {
+		return cc.do(method, req)
+	}
+
+	var resp *http.Response
+	err := cc.hook(ctx, method, func() error {
+		var err error
+		resp, err = cc.do(method, req)
+		return err
+	})
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errRequestSkipped
+	}
+	return resp, nil
+}
+
+// cancelOnClose releases the request context when the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// do performs the request and reports it to stats
+func (cc contextClient) do(method string, req *http.Request) (*http.Response, error) {
+	if cc.stats == nil {
+		return cc.base.Do(req)
+	}
+
+	start := time.Now()
+	resp, err := cc.base.Do(req)
+	cc.stats.ObserveLatency(method, time.Since(start))
+
+	code := 0
+	if resp != nil {
+		code = resp.StatusCode
+	}
+	cc.stats.IncSend(method, code)
+	return resp, err
+}
+
+// apiClient returns the HTTP client for Bot API requests made with ctx
+func (c *Client) apiClient(ctx context.Context) tgbotapi.HTTPClient {
+	if ctx == nil {
+		ctx = c.baseContext()
+	}
+	return contextClient{
+		base:    c.httpClient,
+		ctx:     ctx,
+		timeout: c.requestTimeout,
+		hook:    c.requestHook,
+		stats:   c.stats,
+	}
+}
+
+// bindContext returns a copy of bot that makes requests with ctx
+func (c *Client) bindContext(ctx context.Context, bot *tgbotapi.BotAPI) *tgbotapi.BotAPI {
+	bound := *bot
+	bound.Client = c.apiClient(ctx)
+	return &bound
+}
+
+// botFor returns the primary bot bound to ctx
+func (c *Client) botFor(ctx context.Context) *tgbotapi.BotAPI {
+	return c.bindContext(ctx, c.currentBot())
+}
@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// InlineQueryResult represents one result of an inline query
+type InlineQueryResult struct {
+	Type        string // article, photo, gif
+	ID          string // Unique result identifier, up to 64 bytes
+	Title       string // Result title, required for article
+	Description string // Short description of the result
+
+	// Article message content
+	MessageText string // Text of the message sent when the article is chosen
+	ParseMode   string // Parse mode of MessageText or Caption
+
+	// Photo and gif
+	URL      string // Photo or gif URL, article URL for articles
+	ThumbURL string // Thumbnail URL, required for gif
+	Caption  string // Optional caption
+
+	ReplyMarkup *InlineKeyboardMarkup // Optional inline keyboard attached to the message
+}
+
+// inlineQueryResult converts InlineQueryResult to the Bot API format
+// The reply markup is added to the encoded result, since tgbotapi results accept
+// only tgbotapi.InlineKeyboardMarkup, which has no web_app and copy_text buttons
+func (r InlineQueryResult) inlineQueryResult() (interface{}, error) {
+	result, err := r.tgbotapiResult()
+	if err != nil || r.ReplyMarkup == nil {
+		return result, err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["reply_markup"], err = json.Marshal(r.ReplyMarkup); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// tgbotapiResult converts InlineQueryResult without the reply markup to the matching tgbotapi result type
+func (r InlineQueryResult) tgbotapiResult() (interface{}, error) {
+	switch r.Type {
+	case "article":
+		return tgbotapi.InlineQueryResultArticle{
+			Type:  "article",
+			ID:    r.ID,
+			Title: r.Title,
+			InputMessageContent: tgbotapi.InputTextMessageContent{
+				Text:      r.MessageText,
+				ParseMode: r.ParseMode,
+			},
+			URL:         r.URL,
+			Description: r.Description,
+			ThumbURL:    r.ThumbURL,
+		}, nil
+	case "photo":
+		return tgbotapi.InlineQueryResultPhoto{
+			Type:        "photo",
+			ID:          r.ID,
+			URL:         r.URL,
+			ThumbURL:    r.ThumbURL,
+			Title:       r.Title,
+			Description: r.Description,
+			Caption:     r.Caption,
+			ParseMode:   r.ParseMode,
+		}, nil
+	case "gif":
+		return tgbotapi.InlineQueryResultGIF{
+			Type:      "gif",
+			ID:        r.ID,
+			URL:       r.URL,
+			ThumbURL:  r.ThumbURL,
+			Title:     r.Title,
+			Caption:   r.Caption,
+			ParseMode: r.ParseMode,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported inline query result type: %q", r.Type)
+	}
+}
+
+// AnswerInlineQuery sends results for an inline query
+// Supported options: cache_time, is_personal, next_offset, switch_pm_text, switch_pm_parameter
+func (c *Client) AnswerInlineQuery(ctx context.Context, inlineQueryID string, results []InlineQueryResult, opts map[string]interface{}) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	config := tgbotapi.InlineConfig{
+		InlineQueryID: inlineQueryID,
+		Results:       make([]interface{}, 0, len(results)),
+	}
+	for _, r := range results {
+		result, err := r.inlineQueryResult()
+		if err != nil {
+			return err
+		}
+		config.Results = append(config.Results, result)
+	}
+
+	if cacheTime, ok := asInt(opts["cache_time"]); ok {
+		config.CacheTime = cacheTime
+	}
+	if isPersonal, ok := opts["is_personal"].(bool); ok {
+		config.IsPersonal = isPersonal
+	}
+	if nextOffset, ok := opts["next_offset"].(string); ok {
+		config.NextOffset = nextOffset
+	}
+	if text, ok := opts["switch_pm_text"].(string); ok {
+		config.SwitchPMText = text
+	}
+	if param, ok := opts["switch_pm_parameter"].(string); ok {
+		config.SwitchPMParameter = param
+	}
+
+	_, err := c.botFor(ctx).Request(config)
+	return c.wrapError(err)
+}
@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CreateChatInviteLink creates an additional invite link for a chat
+// Supported options: name, expire_date (unix time), member_limit (1-99999), creates_join_request
+// member_limit can't be combined with creates_join_request
+func (c *Client) CreateChatInviteLink(ctx context.Context, chatID int64, opts map[string]interface{}) (*ChatInviteLink, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	config := tgbotapi.CreateChatInviteLinkConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+	}
+	config.Name, config.ExpireDate, config.MemberLimit, config.CreatesJoinRequest = inviteLinkOptions(opts)
+
+	resp, err := c.botFor(ctx).Request(config)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+	return decodeInviteLink(resp.Result)
+}
+
+// EditChatInviteLink edits a non-primary invite link created by the bot
+// Supports the same options as CreateChatInviteLink
+func (c *Client) EditChatInviteLink(ctx context.Context, chatID int64, inviteLink string, opts map[string]interface{}) (*ChatInviteLink, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	config := tgbotapi.EditChatInviteLinkConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		InviteLink: inviteLink,
+	}
+	config.Name, config.ExpireDate, config.MemberLimit, config.CreatesJoinRequest = inviteLinkOptions(opts)
+
+	resp, err := c.botFor(ctx).Request(config)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+	return decodeInviteLink(resp.Result)
+}
+
+// RevokeChatInviteLink revokes an invite link created by the bot
+// If the primary link is revoked, a new one is generated
+func (c *Client) RevokeChatInviteLink(ctx context.Context, chatID int64, inviteLink string) (*ChatInviteLink, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.botFor(ctx).Request(tgbotapi.RevokeChatInviteLinkConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		InviteLink: inviteLink,
+	})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+	return decodeInviteLink(resp.Result)
+}
+
+// inviteLinkOptions reads options of invite link create and edit methods
+func inviteLinkOptions(opts map[string]interface{}) (name string, expireDate, memberLimit int, createsJoinRequest bool) {
+	name, _ = opts["name"].(string)
+	expireDate, _ = asInt(opts["expire_date"])
+	memberLimit, _ = asInt(opts["member_limit"])
+	createsJoinRequest, _ = opts["creates_join_request"].(bool)
+	return name, expireDate, memberLimit, createsJoinRequest
+}
+
+// decodeInviteLink decodes the ChatInviteLink result of invite link methods
+func decodeInviteLink(result json.RawMessage) (*ChatInviteLink, error) {
+	var link ChatInviteLink
+	if err := json.Unmarshal(result, &link); err != nil {
+		return nil, fmt.Errorf("failed to decode chat invite link: %w", err)
+	}
+	return &link, nil
+}
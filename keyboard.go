@@ -0,0 +1,79 @@
+package telegram
+
+// InlineKeyboardBuilder builds an inline keyboard row by row
+// Buttons are added to the current row, Row starts a new one
+type InlineKeyboardBuilder struct {
+	rows [][]InlineKeyboardButton
+	row  []InlineKeyboardButton
+}
+
+// NewInlineKeyboard creates an empty inline keyboard builder
+func NewInlineKeyboard() *InlineKeyboardBuilder {
+	return &InlineKeyboardBuilder{}
+}
+
+// Button adds an arbitrary button to the current row
+func (b *InlineKeyboardBuilder) Button(button InlineKeyboardButton) *InlineKeyboardBuilder {
+	b.row = append(b.row, button)
+	return b
+}
+
+// ButtonURL adds a button that opens a URL
+func (b *InlineKeyboardBuilder) ButtonURL(text, url string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, URL: url})
+}
+
+// ButtonData adds a button that sends a callback query with data
+func (b *InlineKeyboardBuilder) ButtonData(text, data string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, CallbackData: data})
+}
+
+// ButtonWebApp adds a button that opens a Web App (Mini App)
+func (b *InlineKeyboardBuilder) ButtonWebApp(text, url string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}})
+}
+
+// ButtonLoginURL adds a button that authorizes the user on a website
+func (b *InlineKeyboardBuilder) ButtonLoginURL(text string, login LoginURL) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, LoginURL: &login})
+}
+
+// ButtonSwitchInline adds a button that lets the user pick a chat and starts an inline query there
+func (b *InlineKeyboardBuilder) ButtonSwitchInline(text, query string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, SwitchInlineQuery: &query})
+}
+
+// ButtonSwitchInlineCurrentChat adds a button that starts an inline query in the current chat
+func (b *InlineKeyboardBuilder) ButtonSwitchInlineCurrentChat(text, query string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: &query})
+}
+
+// ButtonCopyText adds a button that copies text to the clipboard
+func (b *InlineKeyboardBuilder) ButtonCopyText(text, copyText string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, CopyText: &CopyTextButton{Text: copyText}})
+}
+
+// ButtonPay adds a pay button, it must be the first button of an invoice keyboard
+func (b *InlineKeyboardBuilder) ButtonPay(text string) *InlineKeyboardBuilder {
+	return b.Button(InlineKeyboardButton{Text: text, Pay: true})
+}
+
+// Row finishes the current row, empty rows are skipped
+func (b *InlineKeyboardBuilder) Row() *InlineKeyboardBuilder {
+	if len(b.row) > 0 {
+		b.rows = append(b.rows, b.row)
+		b.row = nil
+	}
+	return b
+}
+
+// Build returns the keyboard, finishing the current row
+// The result can be passed as reply_markup to any send or edit method. Our type is used
+// instead of tgbotapi.InlineKeyboardMarkup since the latter has no web_app and copy_text buttons
+func (b *InlineKeyboardBuilder) Build() InlineKeyboardMarkup {
+	b.Row()
+
+	rows := make([][]InlineKeyboardButton, len(b.rows))
+	copy(rows, b.rows)
+	return InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,190 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+)
+
+// SendLongMessage sends text that may exceed the message length limit as several messages
+// Text is split on paragraph, line or word boundaries. With MarkdownV2 parse mode
+// it is never split inside a formatting block, link or code fence, unless a single
+// block doesn't fit into one message. reply_to_message_id is applied to the first
+// message and reply_markup to the last one. The entities option is not supported
+func (c *Client) SendLongMessage(ctx context.Context, chatID int64, text string, opts map[string]interface{}) ([]*Message, error) {
+	if _, ok := opts["entities"]; ok {
+		return nil, errors.New("SendLongMessage doesn't support entities, use parse_mode")
+	}
+
+	markdown := opts["parse_mode"] == ParseModeMarkdownV2
+	parts := splitMessageText(text, MaxMessageLength, markdown)
+
+	messages := make([]*Message, 0, len(parts))
+	for i, part := range parts {
+		if err := ctx.Err(); err != nil {
+			return messages, err
+		}
+
+		partOpts := make(map[string]interface{}, len(opts))
+		for k, v := range opts {
+			if (k == "reply_to_message_id" || k == "reply_parameters") && i > 0 {
+				continue
+			}
+			if k == "reply_markup" && i < len(parts)-1 {
+				continue
+			}
+			partOpts[k] = v
+		}
+
+		msg, err := c.SendMessage(ctx, chatID, part, partOpts)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// splitMessageText splits text into parts of at most limit UTF-16 code units
+func splitMessageText(text string, limit int, markdown bool) []string {
+	runes := []rune(text)
+
+	var safe []bool
+	if markdown {
+		safe = markdownCutPoints(runes)
+	}
+	canCut := func(i int) bool {
+		return safe == nil || safe[i]
+	}
+
+	var parts []string
+	start := 0
+	for {
+		// Find how many runes fit into the limit
+		end, units := start, 0
+		for end < len(runes) {
+			n := 1
+			if runes[end] >= 0x10000 {
+				n = 2
+			}
+			if units+n > limit {
+				break
+			}
+			units += n
+			end++
+		}
+
+		if end == len(runes) {
+			parts = append(parts, string(runes[start:]))
+			return parts
+		}
+
+		cut, skip := findTextCut(runes, start, end, canCut)
+		parts = append(parts, string(runes[start:cut]))
+		start = cut + skip
+	}
+}
+
+// findTextCut finds where to split runes[start:end] and how many separator runes to drop
+// Paragraph breaks are preferred over line breaks, and line breaks over spaces
+func findTextCut(runes []rune, start, end int, canCut func(int) bool) (cut, skip int) {
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		sepRunes := []rune(sep)
+		from := end
+		if from > len(runes)-len(sepRunes) {
+			from = len(runes) - len(sepRunes)
+		}
+		for i := from; i > start; i-- {
+			if canCut(i) && hasRunePrefix(runes[i:], sepRunes) {
+				return i, len(sepRunes)
+			}
+		}
+	}
+
+	for i := end; i > start; i-- {
+		if canCut(i) {
+			return i, 0
+		}
+	}
+
+	// A single block is longer than the limit, it has to be split anyway
+	return end, 0
+}
+
+// markdownCutPoints reports for every position of MarkdownV2 text whether it can be split there
+// Positions inside formatting blocks, links, code and escape sequences can't be split
+func markdownCutPoints(runes []rune) []bool {
+	safe := make([]bool, len(runes)+1)
+	open := make(map[string]bool)
+	toggle := func(marker string) {
+		if open[marker] {
+			delete(open, marker)
+		} else {
+			open[marker] = true
+		}
+	}
+
+	i := 0
+	for i < len(runes) {
+		safe[i] = len(open) == 0
+
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes):
+			i += 2
+
+		case hasRunePrefix(runes[i:], []rune("```")):
+			end := findClosingCodeBlock(runes, i+3)
+			if end == -1 {
+				i += 3
+				continue
+			}
+			i = end + 3
+
+		case runes[i] == '`':
+			end := findClosingChar(runes, i+1, '`')
+			if end == -1 {
+				i++
+				continue
+			}
+			i = end + 1
+
+		case runes[i] == '[' || (runes[i] == '!' && i+1 < len(runes) && runes[i+1] == '['):
+			start := i
+			if runes[i] == '!' {
+				start++
+			}
+			_, end := parseLinkMarkdown(runes, start)
+			if end == -1 {
+				i++
+				continue
+			}
+			i = end + 1
+
+		case hasRunePrefix(runes[i:], []rune("||")) || hasRunePrefix(runes[i:], []rune("__")):
+			toggle(string(runes[i : i+2]))
+			i += 2
+
+		case runes[i] == '*' || runes[i] == '_' || runes[i] == '~':
+			toggle(string(runes[i]))
+			i++
+
+		default:
+			i++
+		}
+	}
+	safe[len(runes)] = true
+
+	return safe
+}
+
+// hasRunePrefix reports whether runes start with prefix
+func hasRunePrefix(runes, prefix []rune) bool {
+	if len(runes) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// extraParams collects send options that tgbotapi configs have no fields for:
+// message_thread_id, which must be a positive integer, protect_content and
+// business_connection_id of sends on behalf of a business account
+func extraParams(opts map[string]interface{}) (url.Values, error) {
+	extra := url.Values{}
+	if v, ok := opts["message_thread_id"]; ok {
+		threadID, ok := asInt(v)
+		if !ok || threadID <= 0 {
+			return nil, fmt.Errorf("message_thread_id must be a positive integer, got %v", v)
+		}
+		extra.Set("message_thread_id", fmt.Sprint(threadID))
+	}
+	if protect, ok := opts["protect_content"].(bool); ok && protect {
+		extra.Set("protect_content", "true")
+	}
+	if connectionID, ok := opts["business_connection_id"].(string); ok && connectionID != "" {
+		extra.Set("business_connection_id", connectionID)
+	}
+	return extra, nil
+}
+
+// inlineMarkupParams encodes an inline keyboard for edit methods as an extra param
+// markup may be InlineKeyboardMarkup or tgbotapi.InlineKeyboardMarkup, anything else is
+// ignored. tgbotapi edit configs accept only their own type, which has no web_app and
+// copy_text buttons, so the keyboard is sent as JSON instead of through the config
+func inlineMarkupParams(markup interface{}) (url.Values, error) {
+	switch markup.(type) {
+	case InlineKeyboardMarkup, tgbotapi.InlineKeyboardMarkup:
+	default:
+		return nil, nil
+	}
+
+	data, err := json.Marshal(markup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reply_markup: %w", err)
+	}
+	return url.Values{"reply_markup": {string(data)}}, nil
+}
+
+// addExtraParams merges extra params into params of a raw request
+func addExtraParams(params tgbotapi.Params, extra url.Values) {
+	for key := range extra {
+		params[key] = extra.Get(key)
+	}
+}
+
+// paramsClient adds extra parameters to the query string of every request
+// Telegram reads method parameters from the query string as well as from the body
+type paramsClient struct {
+	base  tgbotapi.HTTPClient
+	extra url.Values
+}
+
+// Do implements tgbotapi.HTTPClient
+func (p paramsClient) Do(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	for key, values := range p.extra {
+		for _, v := range values {
+			query.Add(key, v)
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+	return p.base.Do(req)
+}
+
+// withExtraParams returns a copy of bot that sends extra params with every request
+func withExtraParams(bot *tgbotapi.BotAPI, extra url.Values) *tgbotapi.BotAPI {
+	if len(extra) == 0 {
+		return bot
+	}
+	withParams := *bot
+	withParams.Client = paramsClient{base: bot.Client, extra: extra}
+	return &withParams
+}
@@ -0,0 +1,134 @@
+package telegram
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// InvoiceConfig describes an invoice sent by SendInvoice
+type InvoiceConfig struct {
+	Title         string         // Product name, 1-32 characters
+	Description   string         // Product description, 1-255 characters
+	Payload       string         // Bot-defined payload, not shown to the user
+	ProviderToken string         // Payment provider token, empty for Telegram Stars
+	Currency      string         // Three-letter ISO 4217 currency code, XTR for Telegram Stars
+	Prices        []LabeledPrice // Price breakdown
+
+	StartParameter      string // Optional deep-linking parameter
+	PhotoURL            string // Optional product photo URL
+	NeedName            bool   // Request user's full name
+	NeedPhoneNumber     bool   // Request user's phone number
+	NeedEmail           bool   // Request user's email
+	NeedShippingAddress bool   // Request user's shipping address
+	IsFlexible          bool   // Final price depends on the shipping method
+}
+
+// SendInvoice sends an invoice
+func (c *Client) SendInvoice(ctx context.Context, chatID int64, invoice InvoiceConfig, opts map[string]interface{}) (*Message, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	prices := make([]tgbotapi.LabeledPrice, 0, len(invoice.Prices))
+	for _, p := range invoice.Prices {
+		prices = append(prices, tgbotapi.LabeledPrice{Label: p.Label, Amount: p.Amount})
+	}
+
+	msg := tgbotapi.NewInvoice(chatID, invoice.Title, invoice.Description, invoice.Payload,
+		invoice.ProviderToken, invoice.StartParameter, invoice.Currency, prices)
+	msg.PhotoURL = invoice.PhotoURL
+	msg.NeedName = invoice.NeedName
+	msg.NeedPhoneNumber = invoice.NeedPhoneNumber
+	msg.NeedEmail = invoice.NeedEmail
+	msg.NeedShippingAddress = invoice.NeedShippingAddress
+	msg.IsFlexible = invoice.IsFlexible
+	// tgbotapi always sends suggested_tip_amounts, Telegram rejects null
+	msg.SuggestedTipAmounts = []int{}
+
+	applyBaseOptions(&msg.BaseChat, opts)
+
+	sent, err := c.send(ctx, msg, opts)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return convertSentMessage(&sent), nil
+}
+
+// AnswerShippingQuery replies to a shipping query of a flexible invoice
+// Pass ok=false with errorMessage when delivery to the address is impossible
+func (c *Client) AnswerShippingQuery(ctx context.Context, shippingQueryID string, ok bool, options []ShippingOption, errorMessage string) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	config := tgbotapi.ShippingConfig{
+		ShippingQueryID: shippingQueryID,
+		OK:              ok,
+		ErrorMessage:    errorMessage,
+	}
+	for _, o := range options {
+		prices := make([]tgbotapi.LabeledPrice, 0, len(o.Prices))
+		for _, p := range o.Prices {
+			prices = append(prices, tgbotapi.LabeledPrice{Label: p.Label, Amount: p.Amount})
+		}
+		config.ShippingOptions = append(config.ShippingOptions, tgbotapi.ShippingOption{
+			ID:     o.ID,
+			Title:  o.Title,
+			Prices: prices,
+		})
+	}
+
+	_, err := c.botFor(ctx).Request(config)
+	return c.wrapError(err)
+}
+
+// AnswerPreCheckoutQuery confirms or rejects an order
+// Telegram expects the answer within 10 seconds of receiving the query
+func (c *Client) AnswerPreCheckoutQuery(ctx context.Context, preCheckoutQueryID string, ok bool, errorMessage string) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	_, err := c.botFor(ctx).Request(tgbotapi.PreCheckoutConfig{
+		PreCheckoutQueryID: preCheckoutQueryID,
+		OK:                 ok,
+		ErrorMessage:       errorMessage,
+	})
+	return c.wrapError(err)
+}
+
+// convertSuccessfulPayment converts tgbotapi SuccessfulPayment to our type
+func convertSuccessfulPayment(p *tgbotapi.SuccessfulPayment) *SuccessfulPayment {
+	if p == nil {
+		return nil
+	}
+
+	result := &SuccessfulPayment{
+		Currency:                p.Currency,
+		TotalAmount:             p.TotalAmount,
+		InvoicePayload:          p.InvoicePayload,
+		ShippingOptionID:        p.ShippingOptionID,
+		TelegramPaymentChargeID: p.TelegramPaymentChargeID,
+		ProviderPaymentChargeID: p.ProviderPaymentChargeID,
+	}
+	if p.OrderInfo != nil {
+		result.OrderInfo = &OrderInfo{
+			Name:        p.OrderInfo.Name,
+			PhoneNumber: p.OrderInfo.PhoneNumber,
+			Email:       p.OrderInfo.Email,
+		}
+		if a := p.OrderInfo.ShippingAddress; a != nil {
+			result.OrderInfo.ShippingAddress = &ShippingAddress{
+				CountryCode: a.CountryCode,
+				State:       a.State,
+				City:        a.City,
+				StreetLine1: a.StreetLine1,
+				StreetLine2: a.StreetLine2,
+				PostCode:    a.PostCode,
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// defaultPoolTokenRate is the Telegram limit of messages per second of one bot token
+const defaultPoolTokenRate = 30
+
+// WithTokenPoolRate sets how many messages per second every token of the pool may send
+// Each token has its own token bucket, 30 per second by default. 0 or less disables pacing
+func WithTokenPoolRate(perSecond rate.Limit) Option {
+	return func(c *Client) {
+		c.poolRate = perSecond
+	}
+}
+
+// pooledBot is a bot instance from the token pool with its own rate limit state
+type pooledBot struct {
+	bot     *tgbotapi.BotAPI
+	index   int           // Position of the token in WithTokenPool, for logs
+	limiter *rate.Limiter // Paces sends of the token, see WithTokenPoolRate
+
+	mu           sync.Mutex
+	limitedUntil time.Time
+	disabled     bool // The token got 401 and is not used anymore
+}
+
+// available reports whether the bot is not disabled or rate limited at the given moment
+func (p *pooledBot) available(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.disabled && !now.Before(p.limitedUntil)
+}
+
+// disable stops using the bot and reports whether it was enabled before
+func (p *pooledBot) disable() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wasEnabled := !p.disabled
+	p.disabled = true
+	return wasEnabled
+}
+
+// limit marks the bot as rate limited for the given duration
+func (p *pooledBot) limit(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.limitedUntil) {
+		p.limitedUntil = until
+	}
+}
+
+// tokenPool round-robins sends across several bot tokens
+type tokenPool struct {
+	bots []*pooledBot
+	next uint64
+}
+
+// newTokenPool creates bot instances for all tokens of the pool
+// Called by initBot with mu held
+func (c *Client) newTokenPool(tokens []string) (*tokenPool, error) {
+	limit := c.poolRate
+	if limit <= 0 {
+		limit = rate.Inf
+	}
+
+	pool := &tokenPool{}
+	for i, token := range tokens {
+		bot, err := c.newBot(c.baseContext(), token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pool bot #%d: %w", i, redactError(err, c.tokensLocked()))
+		}
+		bot.Debug = c.debug
+		pool.bots = append(pool.bots, &pooledBot{
+			bot:     bot,
+			index:   i,
+			limiter: rate.NewLimiter(limit, burstOf(limit)),
+		})
+	}
+	return pool, nil
+}
+
+// enabled reports whether any token of the pool is not disabled
+func (p *tokenPool) enabled() bool {
+	for _, b := range p.bots {
+		b.mu.Lock()
+		disabled := b.disabled
+		b.mu.Unlock()
+		if !disabled {
+			return true
+		}
+	}
+	return false
+}
+
+// pick returns the next bot that is not disabled or rate limited, or nil if all of them are
+func (p *tokenPool) pick() *pooledBot {
+	now := time.Now()
+	start := atomic.AddUint64(&p.next, 1) - 1
+	for i := 0; i < len(p.bots); i++ {
+		candidate := p.bots[(start+uint64(i))%uint64(len(p.bots))]
+		if candidate.available(now) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// withSender runs fn with the bot that should send the next message
+// Without a token pool the primary bot is used. With a pool, tokens are used
+// round-robin, each paced by its own token bucket, and a token that hit 429 is
+// skipped until retry_after passes. A pool token that got 401 is disabled, only 401 of the primary token count
+// toward the circuit breaker. Once every pool token is disabled the primary
+// token sends. The send to chatID is paced by the rate limiter first, see WithRateLimiter
+func (c *Client) withSender(ctx context.Context, chatID int64, fn func(bot *tgbotapi.BotAPI) error) error {
+	if !c.circuit.allow() {
+		return ErrClientUnauthorized
+	}
+	if err := c.waitRate(ctx, chatID); err != nil {
+		return err
+	}
+
+	if c.pool == nil || !c.pool.enabled() {
+		err := fn(c.botFor(ctx))
+		c.recordResult(err)
+		return err
+	}
+
+	sender := c.pool.pick()
+	if sender == nil {
+		return &APIError{
+			Code:        429,
+			Description: "all tokens of the pool are rate limited",
+		}
+	}
+
+	waitCtx := ctx
+	if waitCtx == nil {
+		waitCtx = c.baseContext()
+	}
+	if err := sender.limiter.Wait(waitCtx); err != nil {
+		return err
+	}
+
+	err := fn(c.bindContext(ctx, sender.bot))
+	if tgErr, ok := err.(*tgbotapi.Error); ok {
+		switch tgErr.Code {
+		case 401:
+			if sender.disable() && c.logger != nil {
+				c.logger.Error("telegram pool token is unauthorized, disabling it",
+					zap.Int("pool_index", sender.index),
+				)
+			}
+			return err
+		case 429:
+			sender.limit(time.Duration(tgErr.RetryAfter) * time.Second)
+		}
+	}
+	c.recordResult(err)
+	return err
+}
+
+// send sends a message via withSender
+// opts may carry params tgbotapi configs don't support, see extraParams
+func (c *Client) send(ctx context.Context, msg tgbotapi.Chattable, opts map[string]interface{}) (sentMessage, error) {
+	ctx = callContext(ctx, opts)
+
+	extra, err := extraParams(opts)
+	if err != nil {
+		return sentMessage{}, err
+	}
+
+	var sent sentMessage
+	err = c.withSender(ctx, chatIDOf(msg), func(bot *tgbotapi.BotAPI) error {
+		var err error
+		sent, err = requestMessage(withExtraParams(bot, extra), msg)
+		return err
+	})
+	return sent, err
+}
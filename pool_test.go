@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestPoolTokenUnauthorizedDoesNotTripCircuit(t *testing.T) {
+	const revoked = "222:revoked"
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, revoked) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"ok":false,"error_code":401,"description":"Unauthorized"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}), WithTokenPool([]string{revoked, "333:healthy"}), WithUnauthorizedThreshold(1))
+
+	ctx := context.Background()
+	failures := 0
+	for i := 0; i < 6; i++ {
+		if _, err := client.SendMessage(ctx, 10, "hi", nil); err != nil {
+			if !IsUnauthorizedError(err) {
+				t.Fatalf("SendMessage() error = %v", err)
+			}
+			failures++
+		}
+	}
+
+	if failures != 1 {
+		t.Errorf("got %d failed sends, want the revoked token to fail once and be disabled", failures)
+	}
+	if client.IsCircuitOpen() {
+		t.Error("circuit is open after a pool token 401")
+	}
+}
+
+func TestPoolTokensHaveOwnRateBudget(t *testing.T) {
+	used := make(map[string]int)
+	var mu sync.Mutex
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		used[strings.Split(r.URL.Path, "/")[1]]++
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}), WithTokenPool([]string{"222:first", "333:second"}), WithTokenPoolRate(rate.Every(time.Hour)))
+
+	// Every token has a budget of one message, so two sends go out right away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendMessage(ctx, 10, "hi", nil); err != nil {
+			t.Fatalf("send #%d: %v", i, err)
+		}
+	}
+	if used["bot222:first"] != 1 || used["bot333:second"] != 1 {
+		t.Fatalf("requests per token = %v, want one per token", used)
+	}
+
+	// Both budgets are spent now, the next send has to wait for a token
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	if _, err := client.SendMessage(short, 10, "hi", nil); err == nil {
+		t.Fatal("third send succeeded, want it to wait for the token budget")
+	}
+}
+
+func TestSetTokenBeforeFirstSendKeepsPool(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	client := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":10,"type":"private"}}}`)
+	}), WithTokenPool([]string{"222:first"}))
+
+	ctx := context.Background()
+	if err := client.SetToken(ctx, "444:new"); err != nil {
+		t.Fatalf("SetToken() error = %v", err)
+	}
+	if _, err := client.SendMessage(ctx, 10, "hi", nil); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	// WithSkipGetMe is honoured, so the only request is the send through the pool
+	if len(paths) != 1 || paths[0] != "/bot222:first/sendMessage" {
+		t.Errorf("requests = %v, want one sendMessage with the pool token", paths)
+	}
+	if got := client.currentBot().Token; got != "444:new" {
+		t.Errorf("primary token = %q, want the new one", got)
+	}
+}
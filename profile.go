@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// SetMyName changes the bot's name for users with the given language
+// Empty languageCode sets the default name, empty name removes the localized one
+func (c *Client) SetMyName(ctx context.Context, name, languageCode string) error {
+	return c.setBotProfileText(ctx, "setMyName", "name", name, languageCode)
+}
+
+// GetMyName returns the bot's name for the given language
+func (c *Client) GetMyName(ctx context.Context, languageCode string) (string, error) {
+	return c.getBotProfileText(ctx, "getMyName", "name", languageCode)
+}
+
+// SetMyDescription changes the description shown in an empty chat with the bot
+func (c *Client) SetMyDescription(ctx context.Context, description, languageCode string) error {
+	return c.setBotProfileText(ctx, "setMyDescription", "description", description, languageCode)
+}
+
+// GetMyDescription returns the bot's description for the given language
+func (c *Client) GetMyDescription(ctx context.Context, languageCode string) (string, error) {
+	return c.getBotProfileText(ctx, "getMyDescription", "description", languageCode)
+}
+
+// SetMyShortDescription changes the short description shown on the bot's profile page
+func (c *Client) SetMyShortDescription(ctx context.Context, shortDescription, languageCode string) error {
+	return c.setBotProfileText(ctx, "setMyShortDescription", "short_description", shortDescription, languageCode)
+}
+
+// GetMyShortDescription returns the bot's short description for the given language
+func (c *Client) GetMyShortDescription(ctx context.Context, languageCode string) (string, error) {
+	return c.getBotProfileText(ctx, "getMyShortDescription", "short_description", languageCode)
+}
+
+// setBotProfileText calls one of the setMy* methods that take a single text field
+func (c *Client) setBotProfileText(ctx context.Context, method, key, text, languageCode string) error {
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonEmpty(key, text)
+	params.AddNonEmpty("language_code", languageCode)
+
+	_, err := c.botFor(ctx).MakeRequest(method, params)
+	return c.wrapError(err)
+}
+
+// getBotProfileText calls one of the getMy* methods and reads the text field from the result
+func (c *Client) getBotProfileText(ctx context.Context, method, key, languageCode string) (string, error) {
+	if err := c.initBot(); err != nil {
+		return "", err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonEmpty("language_code", languageCode)
+
+	resp, err := c.botFor(ctx).MakeRequest(method, params)
+	if err != nil {
+		return "", c.wrapError(err)
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	return result[key], nil
+}
@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// chatLimiterIdle is how long a per-chat limiter is kept after the last send to the chat
+const chatLimiterIdle = time.Minute
+
+// RateLimiter paces sends of the Client
+// Wait blocks until a message may be sent to the chat or ctx is done
+type RateLimiter interface {
+	Wait(ctx context.Context, chatID int64) error
+}
+
+// noopRateLimiter is the default RateLimiter, it never blocks
+type noopRateLimiter struct{}
+
+// Wait implements RateLimiter
+func (noopRateLimiter) Wait(ctx context.Context, chatID int64) error {
+	return nil
+}
+
+// WithRateLimiter paces every send through a token bucket of the whole bot and one per chat
+// Telegram allows about 30 messages per second in total and 1 per second to one chat.
+// A limit of 0 or less disables the bucket. Sends are not paced by default
+func WithRateLimiter(global, perChat rate.Limit) Option {
+	return func(c *Client) {
+		c.rateLimiter = NewChatRateLimiter(global, perChat)
+	}
+}
+
+// RateLimiter returns the limiter pacing sends, e.g. to pace requests made with Call
+func (c *Client) RateLimiter() RateLimiter {
+	return c.rateLimiter
+}
+
+// ChatRateLimiter is a RateLimiter with a global token bucket and one bucket per chat
+type ChatRateLimiter struct {
+	global  *rate.Limiter
+	perChat rate.Limit
+
+	mu        sync.Mutex
+	chats     map[int64]*chatLimiter
+	lastPrune time.Time
+}
+
+// chatLimiter is the token bucket of one chat
+type chatLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// NewChatRateLimiter creates a limiter allowing global sends per second in total and perChat per chat
+func NewChatRateLimiter(global, perChat rate.Limit) *ChatRateLimiter {
+	if global <= 0 {
+		global = rate.Inf
+	}
+	if perChat <= 0 {
+		perChat = rate.Inf
+	}
+
+	return &ChatRateLimiter{
+		global:  rate.NewLimiter(global, burstOf(global)),
+		perChat: perChat,
+		chats:   make(map[int64]*chatLimiter),
+	}
+}
+
+// Wait implements RateLimiter
+// The chat bucket is taken first, so sends waiting for a busy chat don't hold global tokens
+func (l *ChatRateLimiter) Wait(ctx context.Context, chatID int64) error {
+	if chatID != 0 && l.perChat != rate.Inf {
+		if err := l.chat(chatID).Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return l.global.Wait(ctx)
+}
+
+// chat returns the token bucket of the chat, creating it on first use
+func (l *ChatRateLimiter) chat(chatID int64) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastPrune) >= chatLimiterIdle {
+		l.prune(now)
+	}
+
+	cl, ok := l.chats[chatID]
+	if !ok {
+		cl = &chatLimiter{limiter: rate.NewLimiter(l.perChat, 1)}
+		l.chats[chatID] = cl
+	}
+	cl.lastUsed = now
+	return cl.limiter
+}
+
+// prune drops buckets of chats idle for chatLimiterIdle, must be called with mu held
+// An idle bucket is full, so a new one behaves the same
+func (l *ChatRateLimiter) prune(now time.Time) {
+	for chatID, cl := range l.chats {
+		if now.Sub(cl.lastUsed) >= chatLimiterIdle {
+			delete(l.chats, chatID)
+		}
+	}
+	l.lastPrune = now
+}
+
+// burstOf allows a second worth of tokens at once, at least one
+func burstOf(limit rate.Limit) int {
+	if limit == rate.Inf || limit < 1 {
+		return 1
+	}
+	return int(math.Ceil(float64(limit)))
+}
+
+// waitRate blocks until the rate limiter allows a send to the chat
+func (c *Client) waitRate(ctx context.Context, chatID int64) error {
+	if ctx == nil {
+		ctx = c.baseContext()
+	}
+	return c.rateLimiter.Wait(ctx, chatID)
+}
+
+// chatIDOf returns the chat_id of a tgbotapi send config, 0 if it has none
+// All send configs embed BaseChat or have their own ChatID field
+func chatIDOf(msg interface{}) int64 {
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+
+	field := v.FieldByName("ChatID")
+	if !field.IsValid() || field.Kind() != reflect.Int64 {
+		return 0
+	}
+	return field.Int()
+}
@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactedToken replaces bot tokens in logged and returned strings
+const redactedToken = "***"
+
+// RedactToken replaces every occurrence of token in s with "***"
+func RedactToken(s, token string) string {
+	if token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, token, redactedToken)
+}
+
+// tokens returns the primary and pool bot tokens
+func (c *Client) tokens() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokensLocked()
+}
+
+// tokensLocked is tokens for callers already holding mu
+func (c *Client) tokensLocked() []string {
+	return append([]string{c.token}, c.poolTokens...)
+}
+
+// redact removes bot tokens from s
+func (c *Client) redact(s string) string {
+	return redactTokens(s, c.tokens())
+}
+
+// redactTokens replaces all of the tokens in s with "***"
+func redactTokens(s string, tokens []string) string {
+	for _, token := range tokens {
+		s = RedactToken(s, token)
+	}
+	return s
+}
+
+// redactError removes bot tokens from the error message
+// Request errors of net/http carry the method URL, which contains the token
+func redactError(err error, tokens []string) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	redacted := redactTokens(msg, tokens)
+	if redacted == msg {
+		return err
+	}
+
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{Op: urlErr.Op, URL: redactTokens(urlErr.URL, tokens), Err: urlErr.Err}
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+// redactedError is an error with bot tokens removed from its message
+// Unwrap keeps errors.Is and errors.As working with the original error
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,94 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf16"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ReplyParameters describes the message to reply to, optionally quoting a part of it
+// Pass it as "reply_parameters" option of SendMessage
+type ReplyParameters struct {
+	MessageID                int64  `json:"message_id"`
+	ChatID                   int64  `json:"chat_id,omitempty"`
+	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply,omitempty"`
+	QuoteText                string `json:"quote,omitempty"`
+	QuotePosition            int    `json:"quote_position,omitempty"` // UTF-16 offset of the quote
+}
+
+// QuoteReply creates ReplyParameters that reply to msg quoting the first occurrence of quote
+// Quote must be an exact substring of the message text or caption, otherwise
+// Telegram rejects the request with QUOTE_TEXT_INVALID
+func QuoteReply(msg *Message, quote string) (ReplyParameters, error) {
+	if msg == nil {
+		return ReplyParameters{}, errors.New("message is nil")
+	}
+	if quote == "" {
+		return ReplyParameters{}, errors.New("quote is empty")
+	}
+
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+
+	idx := strings.Index(text, quote)
+	if idx < 0 {
+		return ReplyParameters{}, errors.New("quote is not a substring of the message")
+	}
+
+	return ReplyParameters{
+		MessageID:     msg.MessageID,
+		QuoteText:     quote,
+		QuotePosition: utf16Len(text[:idx]),
+	}, nil
+}
+
+// utf16Len returns length of text in UTF-16 code units as Telegram counts offsets
+func utf16Len(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+// sendMessageWithReply sends a text message with reply_parameters
+// tgbotapi doesn't support reply_parameters, so the request is made directly
+func (c *Client) sendMessageWithReply(ctx context.Context, msg tgbotapi.MessageConfig, reply ReplyParameters, opts map[string]interface{}) (sentMessage, error) {
+	ctx = callContext(ctx, opts)
+
+	extra, err := extraParams(opts)
+	if err != nil {
+		return sentMessage{}, err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", msg.ChatID)
+	params["text"] = msg.Text
+	params.AddNonEmpty("parse_mode", msg.ParseMode)
+	if len(msg.Entities) > 0 {
+		if err := params.AddInterface("entities", msg.Entities); err != nil {
+			return sentMessage{}, err
+		}
+	}
+	params.AddBool("disable_web_page_preview", msg.DisableWebPagePreview)
+	params.AddBool("disable_notification", msg.DisableNotification)
+	if err := params.AddInterface("reply_markup", msg.ReplyMarkup); err != nil {
+		return sentMessage{}, err
+	}
+	if err := params.AddInterface("reply_parameters", reply); err != nil {
+		return sentMessage{}, err
+	}
+	addExtraParams(params, extra)
+
+	var sent sentMessage
+	err = c.withSender(ctx, msg.ChatID, func(bot *tgbotapi.BotAPI) error {
+		resp, err := bot.MakeRequest("sendMessage", params)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(resp.Result, &sent)
+	})
+	return sent, err
+}
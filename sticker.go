@@ -0,0 +1,170 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxCustomEmojiIDs is the max number of custom emoji resolved by one request
+const maxCustomEmojiIDs = 200
+
+// Sticker formats of UploadStickerFile and InputSticker
+const (
+	StickerFormatStatic   = "static"   // WEBP or PNG, 512px on one side
+	StickerFormatAnimated = "animated" // TGS
+	StickerFormatVideo    = "video"    // WEBM
+)
+
+// InputSticker describes a sticker added to a sticker set
+type InputSticker struct {
+	Sticker   FileSource // Uploaded file, file_id of UploadStickerFile or URL of a static sticker
+	Format    string     // One of the StickerFormat constants
+	EmojiList []string   // 1-20 emoji of the sticker
+	Keywords  []string   // Optional search keywords, regular and custom emoji sets only
+}
+
+// GetStickerSet returns a sticker set by name
+// tgbotapi doesn't know sticker types and formats, so the request is made directly
+func (c *Client) GetStickerSet(ctx context.Context, name string) (*StickerSet, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	params := make(tgbotapi.Params)
+	params["name"] = name
+
+	resp, err := c.botFor(ctx).MakeRequest("getStickerSet", params)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	var set StickerSet
+	if err := json.Unmarshal(resp.Result, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode sticker set: %w", err)
+	}
+	return &set, nil
+}
+
+// GetCustomEmojiStickers returns stickers of custom emoji, e.g. of custom_emoji entities
+// IDs are sent in chunks of 200. Unknown IDs are skipped by Telegram, so the result may be shorter
+func (c *Client) GetCustomEmojiStickers(ctx context.Context, customEmojiIDs []string) ([]Sticker, error) {
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	bot := c.botFor(ctx)
+	stickers := make([]Sticker, 0, len(customEmojiIDs))
+	for start := 0; start < len(customEmojiIDs); start += maxCustomEmojiIDs {
+		end := start + maxCustomEmojiIDs
+		if end > len(customEmojiIDs) {
+			end = len(customEmojiIDs)
+		}
+
+		params := make(tgbotapi.Params)
+		if err := params.AddInterface("custom_emoji_ids", customEmojiIDs[start:end]); err != nil {
+			return nil, err
+		}
+
+		resp, err := bot.MakeRequest("getCustomEmojiStickers", params)
+		if err != nil {
+			return nil, c.wrapError(err)
+		}
+
+		var chunk []Sticker
+		if err := json.Unmarshal(resp.Result, &chunk); err != nil {
+			return nil, fmt.Errorf("failed to decode custom emoji stickers: %w", err)
+		}
+		stickers = append(stickers, chunk...)
+	}
+	return stickers, nil
+}
+
+// UploadStickerFile uploads a sticker file to use it in several sticker set methods
+// format is one of the StickerFormat constants
+func (c *Client) UploadStickerFile(ctx context.Context, userID int64, sticker FileSource, format string) (*FileResponse, error) {
+	if err := validateStickerFormat(format); err != nil {
+		return nil, err
+	}
+	if err := c.initBot(); err != nil {
+		return nil, err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("user_id", userID)
+	params["sticker_format"] = format
+
+	resp, err := c.botFor(ctx).UploadFiles("uploadStickerFile", params, []tgbotapi.RequestFile{
+		{Name: "sticker", Data: sticker.requestFileData()},
+	})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	var file FileResponse
+	if err := json.Unmarshal(resp.Result, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode uploaded sticker: %w", err)
+	}
+	return &file, nil
+}
+
+// AddStickerToSet adds a sticker to a set created by the bot for the user
+// Regular and custom emoji sets hold up to 120 stickers
+func (c *Client) AddStickerToSet(ctx context.Context, userID int64, name string, sticker InputSticker) error {
+	if err := validateStickerFormat(sticker.Format); err != nil {
+		return err
+	}
+	if err := c.initBot(); err != nil {
+		return err
+	}
+
+	input, file := sticker.inputSticker()
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("user_id", userID)
+	params["name"] = name
+	if err := params.AddInterface("sticker", input); err != nil {
+		return err
+	}
+
+	bot := c.botFor(ctx)
+	var err error
+	if file != nil {
+		_, err = bot.UploadFiles("addStickerToSet", params, []tgbotapi.RequestFile{*file})
+	} else {
+		_, err = bot.MakeRequest("addStickerToSet", params)
+	}
+	return c.wrapError(err)
+}
+
+// inputSticker converts InputSticker to the Bot API format
+// Returns the file to upload, if the sticker is not a file_id or URL
+func (s InputSticker) inputSticker() (map[string]interface{}, *tgbotapi.RequestFile) {
+	input := map[string]interface{}{
+		"format":     s.Format,
+		"emoji_list": s.EmojiList,
+	}
+	if len(s.Keywords) > 0 {
+		input["keywords"] = s.Keywords
+	}
+
+	data := s.Sticker.requestFileData()
+	if !data.NeedsUpload() {
+		input["sticker"] = data.SendData()
+		return input, nil
+	}
+
+	input["sticker"] = "attach://sticker-file"
+	return input, &tgbotapi.RequestFile{Name: "sticker-file", Data: data}
+}
+
+// validateStickerFormat checks the sticker format before uploading
+func validateStickerFormat(format string) error {
+	switch format {
+	case StickerFormatStatic, StickerFormatAnimated, StickerFormatVideo:
+		return nil
+	}
+	return fmt.Errorf("invalid sticker format %q, must be static, animated or video", format)
+}
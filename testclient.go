@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// testToken is the bot token of clients created by NewTestClient
+const testToken = "123456:test-token"
+
+// NewTestClient creates a Client that sends every Bot API request to handler
+// instead of the network, for unit tests. No getMe request is made on creation.
+// The handler receives POST requests to /bot<token>/<method> with form encoded
+// or multipart parameters and has to reply with a Bot API response, e.g.
+// {"ok":true,"result":{...}}. See TestMethod
+func NewTestClient(handler http.Handler, opts ...Option) *Client {
+	opts = append([]Option{
+		WithHTTPClient(&http.Client{Transport: handlerTransport{handler: handler}}),
+		WithSkipGetMe(),
+	}, opts...)
+
+	return NewClient(testToken, zap.NewNop(), opts...)
+}
+
+// TestMethod returns the Bot API method of a request received by a NewTestClient handler
+func TestMethod(r *http.Request) string {
+	return path.Base(r.URL.Path)
+}
+
+// handlerTransport serves requests with an http.Handler in process
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	// A handler that gave up on a canceled request acts like a dropped connection
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	return rec.Result(), nil
+}
+
+// newBot creates a bot instance for the token
+// Unless getMe is skipped, the token is validated by a getMe request
+func (c *Client) newBot(ctx context.Context, token string) (*tgbotapi.BotAPI, error) {
+	client := c.apiClient(ctx)
+	if !c.skipGetMe {
+		return tgbotapi.NewBotAPIWithClient(token, c.apiEndpoint, client)
+	}
+
+	// tgbotapi always calls getMe on construction, so it is answered locally
+	bot, err := tgbotapi.NewBotAPIWithClient(token, c.apiEndpoint, offlineSelfClient{token: token})
+	if err != nil {
+		return nil, err
+	}
+	bot.Client = client
+	return bot, nil
+}
+
+// offlineSelfClient answers getMe without a network call
+// The bot ID is taken from the token, the rest of the bot info stays empty
+type offlineSelfClient struct {
+	token string
+}
+
+// Do implements tgbotapi.HTTPClient
+func (o offlineSelfClient) Do(req *http.Request) (*http.Response, error) {
+	id, _ := strconv.ParseInt(strings.SplitN(o.token, ":", 2)[0], 10, 64)
+	body, err := json.Marshal(tgbotapi.APIResponse{
+		Ok:     true,
+		Result: json.RawMessage(`{"id":` + strconv.FormatInt(id, 10) + `,"is_bot":true}`),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
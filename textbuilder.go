@@ -0,0 +1,92 @@
+package telegram
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// TextBuilder builds message text together with formatting entities
+// Unlike Markdown and HTML nothing has to be escaped, which makes it safe for untrusted input.
+// Pass the result to SendMessage with the "entities" option instead of parse_mode
+type TextBuilder struct {
+	text     strings.Builder
+	length   int // Text length in UTF-16 code units
+	entities []MessageEntity
+}
+
+// NewTextBuilder creates an empty text builder
+func NewTextBuilder() *TextBuilder {
+	return &TextBuilder{}
+}
+
+// Plain appends unformatted text
+func (b *TextBuilder) Plain(s string) *TextBuilder {
+	b.text.WriteString(s)
+	b.length += len(utf16.Encode([]rune(s)))
+	return b
+}
+
+// Bold appends bold text
+func (b *TextBuilder) Bold(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "bold"})
+}
+
+// Italic appends italic text
+func (b *TextBuilder) Italic(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "italic"})
+}
+
+// Underline appends underlined text
+func (b *TextBuilder) Underline(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "underline"})
+}
+
+// Strikethrough appends strikethrough text
+func (b *TextBuilder) Strikethrough(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "strikethrough"})
+}
+
+// Spoiler appends text hidden under a spoiler
+func (b *TextBuilder) Spoiler(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "spoiler"})
+}
+
+// Code appends inline monospace text
+func (b *TextBuilder) Code(s string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "code"})
+}
+
+// Pre appends a code block, language is optional
+func (b *TextBuilder) Pre(s, language string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "pre", Language: language})
+}
+
+// Link appends text that opens url
+func (b *TextBuilder) Link(s, url string) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "text_link", URL: url})
+}
+
+// Mention appends text that mentions a user by ID, works for users without username
+func (b *TextBuilder) Mention(s string, userID int64) *TextBuilder {
+	return b.add(s, MessageEntity{Type: "text_mention", User: &User{ID: userID}})
+}
+
+// add appends text covered by the entity
+func (b *TextBuilder) add(s string, entity MessageEntity) *TextBuilder {
+	entity.Offset = b.length
+	b.Plain(s)
+	entity.Length = b.length - entity.Offset
+
+	// Telegram rejects empty entities
+	if entity.Length > 0 {
+		b.entities = append(b.entities, entity)
+	}
+	return b
+}
+
+// Build returns the text and its entities
+func (b *TextBuilder) Build() (string, []MessageEntity) {
+	entities := make([]MessageEntity, len(b.entities))
+	copy(entities, b.entities)
+	return b.text.String(), entities
+}
@@ -12,26 +12,63 @@ type Response struct {
 
 // Message represents a Telegram message
 type Message struct {
-	MessageID       int64           `json:"message_id"`
-	From            *User           `json:"from,omitempty"`
-	Chat            Chat            `json:"chat"`
-	Date            int64           `json:"date"`
-	Text            string          `json:"text,omitempty"`
-	Photo           []PhotoSize     `json:"photo,omitempty"`
-	Document        *Document       `json:"document,omitempty"`
-	Video           *Video          `json:"video,omitempty"`
-	Audio           *Audio          `json:"audio,omitempty"`
-	Voice           *Voice          `json:"voice,omitempty"`
-	VideoNote       *VideoNote      `json:"video_note,omitempty"`
-	Sticker         *Sticker        `json:"sticker,omitempty"`
-	Contact         *Contact        `json:"contact,omitempty"`
-	Location        *Location       `json:"location,omitempty"`
-	Venue           *Venue          `json:"venue,omitempty"`
-	Poll            *Poll           `json:"poll,omitempty"`
-	Dice            *Dice           `json:"dice,omitempty"`
-	Caption         string          `json:"caption,omitempty"`
-	ReplyToMessage  *Message        `json:"reply_to_message,omitempty"`
-	ReplyMarkup     json.RawMessage `json:"reply_markup,omitempty"`
+	MessageID        int64            `json:"message_id"`
+	From             *User            `json:"from,omitempty"`
+	Chat             Chat             `json:"chat"`
+	Date             int64            `json:"date"`
+	Text             string           `json:"text,omitempty"`
+	Photo            []PhotoSize      `json:"photo,omitempty"`
+	Document         *Document        `json:"document,omitempty"`
+	Video            *Video           `json:"video,omitempty"`
+	Animation        *Animation       `json:"animation,omitempty"`
+	Audio            *Audio           `json:"audio,omitempty"`
+	Voice            *Voice           `json:"voice,omitempty"`
+	VideoNote        *VideoNote       `json:"video_note,omitempty"`
+	Sticker          *Sticker         `json:"sticker,omitempty"`
+	Contact          *Contact         `json:"contact,omitempty"`
+	Location         *Location        `json:"location,omitempty"`
+	Venue            *Venue           `json:"venue,omitempty"`
+	Poll             *Poll            `json:"poll,omitempty"`
+	Dice             *Dice            `json:"dice,omitempty"`
+	Caption          string           `json:"caption,omitempty"`
+	Entities         []MessageEntity  `json:"entities,omitempty"`
+	CaptionEntities  []MessageEntity  `json:"caption_entities,omitempty"`
+	ReplyToMessage   *Message         `json:"reply_to_message,omitempty"`
+	EditDate         int64            `json:"edit_date,omitempty"`
+	MediaGroupID     string           `json:"media_group_id,omitempty"`
+	ForwardOrigin    *MessageOrigin   `json:"forward_origin,omitempty"`
+	ViaBot           *User            `json:"via_bot,omitempty"`
+	ReplyMarkup      json.RawMessage  `json:"reply_markup,omitempty"`
+	NewChatMembers   []User           `json:"new_chat_members,omitempty"`
+	LeftChatMember   *User            `json:"left_chat_member,omitempty"`
+	NewChatTitle     string           `json:"new_chat_title,omitempty"`
+	GroupChatCreated bool             `json:"group_chat_created,omitempty"`
+	GiveawayCreated  *GiveawayCreated `json:"giveaway_created,omitempty"`
+	BoostAdded       *ChatBoostAdded  `json:"boost_added,omitempty"`
+
+	// Set on messages of a connected business account, see Update.BusinessMessage
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+
+	// HasProtectedContent means the message can't be forwarded or saved, copies of it fail
+	HasProtectedContent bool `json:"has_protected_content,omitempty"`
+	// IsFromOffline means the message was sent by an implicit action, e.g. as a scheduled
+	// message or an away message of a business account, not by the user directly
+	IsFromOffline bool `json:"is_from_offline,omitempty"`
+
+	SuccessfulPayment *SuccessfulPayment `json:"successful_payment,omitempty"`
+}
+
+// MessageOrigin describes the origin of a forwarded message
+// Type is "user", "hidden_user", "chat" or "channel", which defines the set fields
+type MessageOrigin struct {
+	Type            string `json:"type"`
+	Date            int64  `json:"date"`
+	SenderUser      *User  `json:"sender_user,omitempty"`      // user
+	SenderUserName  string `json:"sender_user_name,omitempty"` // hidden_user
+	SenderChat      *Chat  `json:"sender_chat,omitempty"`      // chat
+	Chat            *Chat  `json:"chat,omitempty"`             // channel
+	MessageID       int64  `json:"message_id,omitempty"`       // channel
+	AuthorSignature string `json:"author_signature,omitempty"` // chat and channel
 }
 
 // User represents a Telegram user or bot
@@ -54,6 +91,112 @@ type Chat struct {
 	LastName  string `json:"last_name,omitempty"`
 }
 
+// ChatFullInfo contains full information about a chat returned by getChat
+type ChatFullInfo struct {
+	Chat
+	Photo                 *ChatPhoto       `json:"photo,omitempty"`
+	Bio                   string           `json:"bio,omitempty"`
+	Description           string           `json:"description,omitempty"`
+	InviteLink            string           `json:"invite_link,omitempty"`
+	PinnedMessage         *Message         `json:"pinned_message,omitempty"`
+	Permissions           *ChatPermissions `json:"permissions,omitempty"`
+	SlowModeDelay         int              `json:"slow_mode_delay,omitempty"`
+	MessageAutoDeleteTime int              `json:"message_auto_delete_time,omitempty"`
+	HasProtectedContent   bool             `json:"has_protected_content,omitempty"`
+	StickerSetName        string           `json:"sticker_set_name,omitempty"`
+	LinkedChatID          int64            `json:"linked_chat_id,omitempty"`
+}
+
+// ChatPhoto represents a chat photo
+type ChatPhoto struct {
+	SmallFileID       string `json:"small_file_id"`
+	SmallFileUniqueID string `json:"small_file_unique_id"`
+	BigFileID         string `json:"big_file_id"`
+	BigFileUniqueID   string `json:"big_file_unique_id"`
+}
+
+// ChatPermissions describes actions that a non-administrator user is allowed to take in a chat
+type ChatPermissions struct {
+	CanSendMessages       bool `json:"can_send_messages,omitempty"`
+	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`
+	CanSendPolls          bool `json:"can_send_polls,omitempty"`
+	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
+	CanChangeInfo         bool `json:"can_change_info,omitempty"`
+	CanInviteUsers        bool `json:"can_invite_users,omitempty"`
+	CanPinMessages        bool `json:"can_pin_messages,omitempty"`
+
+	// Granular media permissions, replacing CanSendMediaMessages
+	CanSendAudios     bool `json:"can_send_audios,omitempty"`
+	CanSendDocuments  bool `json:"can_send_documents,omitempty"`
+	CanSendPhotos     bool `json:"can_send_photos,omitempty"`
+	CanSendVideos     bool `json:"can_send_videos,omitempty"`
+	CanSendVideoNotes bool `json:"can_send_video_notes,omitempty"`
+	CanSendVoiceNotes bool `json:"can_send_voice_notes,omitempty"`
+	CanManageTopics   bool `json:"can_manage_topics,omitempty"`
+}
+
+// ChatAdministratorRights represents the rights of an administrator in a chat
+type ChatAdministratorRights struct {
+	IsAnonymous         bool `json:"is_anonymous,omitempty"`
+	CanManageChat       bool `json:"can_manage_chat,omitempty"`
+	CanChangeInfo       bool `json:"can_change_info,omitempty"`
+	CanPostMessages     bool `json:"can_post_messages,omitempty"`
+	CanEditMessages     bool `json:"can_edit_messages,omitempty"`
+	CanDeleteMessages   bool `json:"can_delete_messages,omitempty"`
+	CanManageVoiceChats bool `json:"can_manage_voice_chats,omitempty"`
+	CanInviteUsers      bool `json:"can_invite_users,omitempty"`
+	CanRestrictMembers  bool `json:"can_restrict_members,omitempty"`
+	CanPinMessages      bool `json:"can_pin_messages,omitempty"`
+	CanPromoteMembers   bool `json:"can_promote_members,omitempty"`
+}
+
+// ChatMember contains information about one member of a chat
+type ChatMember struct {
+	Status      string `json:"status"` // creator, administrator, member, restricted, left, kicked
+	User        *User  `json:"user"`
+	CustomTitle string `json:"custom_title,omitempty"`
+	IsAnonymous bool   `json:"is_anonymous,omitempty"`
+	UntilDate   int64  `json:"until_date,omitempty"`
+	IsMember    bool   `json:"is_member,omitempty"`
+
+	// Administrator rights
+	CanBeEdited         bool `json:"can_be_edited,omitempty"`
+	CanManageChat       bool `json:"can_manage_chat,omitempty"`
+	CanPostMessages     bool `json:"can_post_messages,omitempty"`
+	CanEditMessages     bool `json:"can_edit_messages,omitempty"`
+	CanDeleteMessages   bool `json:"can_delete_messages,omitempty"`
+	CanManageVoiceChats bool `json:"can_manage_voice_chats,omitempty"`
+	CanRestrictMembers  bool `json:"can_restrict_members,omitempty"`
+	CanPromoteMembers   bool `json:"can_promote_members,omitempty"`
+	CanChangeInfo       bool `json:"can_change_info,omitempty"`
+	CanInviteUsers      bool `json:"can_invite_users,omitempty"`
+	CanPinMessages      bool `json:"can_pin_messages,omitempty"`
+
+	// Restricted member permissions
+	CanSendMessages       bool `json:"can_send_messages,omitempty"`
+	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`
+	CanSendPolls          bool `json:"can_send_polls,omitempty"`
+	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
+}
+
+// IsAdmin reports whether the member is the chat creator or an administrator
+func (m *ChatMember) IsAdmin() bool {
+	return m.Status == "creator" || m.Status == "administrator"
+}
+
+// IsInChat reports whether the member is currently in the chat
+func (m *ChatMember) IsInChat() bool {
+	switch m.Status {
+	case "creator", "administrator", "member":
+		return true
+	case "restricted":
+		return m.IsMember
+	}
+	return false
+}
+
 // PhotoSize represents one size of a photo
 type PhotoSize struct {
 	FileID       string `json:"file_id"`
@@ -86,6 +229,19 @@ type Video struct {
 	FileSize     int64      `json:"file_size,omitempty"`
 }
 
+// Animation represents an animation file (GIF or H.264/MPEG-4 AVC video without sound)
+type Animation struct {
+	FileID       string     `json:"file_id"`
+	FileUniqueID string     `json:"file_unique_id"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	Duration     int        `json:"duration"`
+	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
+	FileName     string     `json:"file_name,omitempty"`
+	MimeType     string     `json:"mime_type,omitempty"`
+	FileSize     int64      `json:"file_size,omitempty"`
+}
+
 // Audio represents an audio file
 type Audio struct {
 	FileID       string     `json:"file_id"`
@@ -120,17 +276,29 @@ type VideoNote struct {
 
 // Sticker represents a sticker
 type Sticker struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Type         string     `json:"type"`
-	Width        int        `json:"width"`
-	Height       int        `json:"height"`
-	IsAnimated   bool       `json:"is_animated"`
-	IsVideo      bool       `json:"is_video"`
-	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
-	Emoji        string     `json:"emoji,omitempty"`
-	SetName      string     `json:"set_name,omitempty"`
-	FileSize     int64      `json:"file_size,omitempty"`
+	FileID           string        `json:"file_id"`
+	FileUniqueID     string        `json:"file_unique_id"`
+	Type             string        `json:"type"` // regular, mask or custom_emoji
+	Width            int           `json:"width"`
+	Height           int           `json:"height"`
+	IsAnimated       bool          `json:"is_animated"`
+	IsVideo          bool          `json:"is_video"`
+	Thumbnail        *PhotoSize    `json:"thumbnail,omitempty"`
+	Emoji            string        `json:"emoji,omitempty"`
+	SetName          string        `json:"set_name,omitempty"`
+	PremiumAnimation *FileResponse `json:"premium_animation,omitempty"` // Premium regular stickers only
+	CustomEmojiID    string        `json:"custom_emoji_id,omitempty"`   // Custom emoji stickers only
+	FileSize         int64         `json:"file_size,omitempty"`
+}
+
+// StickerSet represents a sticker set
+// StickerType is "regular", "mask" or "custom_emoji"
+type StickerSet struct {
+	Name        string     `json:"name"`
+	Title       string     `json:"title"`
+	StickerType string     `json:"sticker_type"`
+	Stickers    []Sticker  `json:"stickers"`
+	Thumbnail   *PhotoSize `json:"thumbnail,omitempty"`
 }
 
 // Contact represents a phone contact
@@ -186,6 +354,33 @@ type Dice struct {
 	Value int    `json:"value"`
 }
 
+// GiveawayCreated represents a service message about the creation of a giveaway
+type GiveawayCreated struct {
+	PrizeStarCount int `json:"prize_star_count,omitempty"`
+}
+
+// ChatBoostAdded represents a service message about a user boosting a chat
+type ChatBoostAdded struct {
+	BoostCount int `json:"boost_count"`
+}
+
+// ReactionType describes a reaction: a standard emoji or a custom emoji
+type ReactionType struct {
+	Type          string `json:"type"` // emoji, custom_emoji
+	Emoji         string `json:"emoji,omitempty"`
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
+}
+
+// ReactionEmoji creates a standard emoji reaction
+func ReactionEmoji(emoji string) ReactionType {
+	return ReactionType{Type: "emoji", Emoji: emoji}
+}
+
+// ReactionCustomEmoji creates a custom emoji reaction
+func ReactionCustomEmoji(customEmojiID string) ReactionType {
+	return ReactionType{Type: "custom_emoji", CustomEmojiID: customEmojiID}
+}
+
 // MessageEntity represents one special entity in a text message
 type MessageEntity struct {
 	Type          string `json:"type"`
@@ -211,6 +406,85 @@ type Update struct {
 	Message       *Message       `json:"message,omitempty"`
 	EditedMessage *Message       `json:"edited_message,omitempty"`
 	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+	InlineQuery   *InlineQuery   `json:"inline_query,omitempty"`
+
+	ShippingQuery    *ShippingQuery    `json:"shipping_query,omitempty"`
+	PreCheckoutQuery *PreCheckoutQuery `json:"pre_checkout_query,omitempty"`
+
+	// Member status changes, chat_member has to be listed in allowed_updates to be received
+	MyChatMember *ChatMemberUpdated `json:"my_chat_member,omitempty"`
+	ChatMember   *ChatMemberUpdated `json:"chat_member,omitempty"`
+
+	// Poll state changes and votes in non-anonymous polls sent by the bot
+	Poll       *Poll       `json:"poll,omitempty"`
+	PollAnswer *PollAnswer `json:"poll_answer,omitempty"`
+
+	// Request to join a chat via an invite link with creates_join_request, the bot has to be an admin with can_invite_users
+	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request,omitempty"`
+
+	// Telegram Business: connection changes and messages of connected business accounts
+	// Reply with the business_connection_id option of send methods
+	BusinessConnection      *BusinessConnection      `json:"business_connection,omitempty"`
+	BusinessMessage         *Message                 `json:"business_message,omitempty"`
+	EditedBusinessMessage   *Message                 `json:"edited_business_message,omitempty"`
+	DeletedBusinessMessages *BusinessMessagesDeleted `json:"deleted_business_messages,omitempty"`
+}
+
+// BusinessConnection represents a connection of the bot with a business account
+type BusinessConnection struct {
+	ID         string `json:"id"`
+	User       User   `json:"user"`         // Business account owner
+	UserChatID int64  `json:"user_chat_id"` // Private chat with the owner
+	Date       int64  `json:"date"`
+	CanReply   bool   `json:"can_reply"`
+	IsEnabled  bool   `json:"is_enabled"`
+}
+
+// BusinessMessagesDeleted is received when messages are deleted from a connected business account
+type BusinessMessagesDeleted struct {
+	BusinessConnectionID string  `json:"business_connection_id"`
+	Chat                 Chat    `json:"chat"`
+	MessageIDs           []int64 `json:"message_ids"`
+}
+
+// ChatJoinRequest represents a request to join a chat
+// Approve or decline it with ApproveChatJoinRequest and DeclineChatJoinRequest
+type ChatJoinRequest struct {
+	Chat       Chat            `json:"chat"`
+	From       User            `json:"from"`
+	UserChatID int64           `json:"user_chat_id,omitempty"` // Private chat with the user, usable for 5 minutes
+	Date       int64           `json:"date"`
+	Bio        string          `json:"bio,omitempty"`
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"` // Link used to send the request
+}
+
+// PollAnswer represents a vote in a non-anonymous poll
+// OptionIDs is empty if the user retracted the vote
+type PollAnswer struct {
+	PollID    string `json:"poll_id"`
+	User      *User  `json:"user,omitempty"`
+	VoterChat *Chat  `json:"voter_chat,omitempty"` // Set when voting on behalf of a chat
+	OptionIDs []int  `json:"option_ids"`
+}
+
+// ChatMemberUpdated represents a change of a chat member status
+// In my_chat_member updates the member is the bot itself
+type ChatMemberUpdated struct {
+	Chat          Chat       `json:"chat"`
+	From          User       `json:"from"`
+	Date          int64      `json:"date"`
+	OldChatMember ChatMember `json:"old_chat_member"`
+	NewChatMember ChatMember `json:"new_chat_member"`
+}
+
+// InlineQuery represents an incoming inline query
+type InlineQuery struct {
+	ID       string    `json:"id"`
+	From     User      `json:"from"`
+	Query    string    `json:"query"`
+	Offset   string    `json:"offset"`
+	ChatType string    `json:"chat_type,omitempty"`
+	Location *Location `json:"location,omitempty"`
 }
 
 // CallbackQuery represents an incoming callback query from inline keyboard
@@ -221,6 +495,12 @@ type CallbackQuery struct {
 	InlineMessageID string   `json:"inline_message_id,omitempty"`
 	ChatInstance    string   `json:"chat_instance"`
 	Data            string   `json:"data,omitempty"`
+	GameShortName   string   `json:"game_short_name,omitempty"`
+}
+
+// IsGame reports whether the callback query was sent by a game button
+func (q *CallbackQuery) IsGame() bool {
+	return q.GameShortName != ""
 }
 
 // InlineKeyboardMarkup represents an inline keyboard
@@ -229,24 +509,80 @@ type InlineKeyboardMarkup struct {
 }
 
 // InlineKeyboardButton represents one button of an inline keyboard
+// Exactly one of the optional fields is expected to be set
 type InlineKeyboardButton struct {
-	Text         string `json:"text"`
-	URL          string `json:"url,omitempty"`
-	CallbackData string `json:"callback_data,omitempty"`
+	Text                         string          `json:"text"`
+	URL                          string          `json:"url,omitempty"`
+	CallbackData                 string          `json:"callback_data,omitempty"`
+	WebApp                       *WebAppInfo     `json:"web_app,omitempty"`
+	LoginURL                     *LoginURL       `json:"login_url,omitempty"`
+	SwitchInlineQuery            *string         `json:"switch_inline_query,omitempty"`
+	SwitchInlineQueryCurrentChat *string         `json:"switch_inline_query_current_chat,omitempty"`
+	CopyText                     *CopyTextButton `json:"copy_text,omitempty"`
+	Pay                          bool            `json:"pay,omitempty"`
+
+	SwitchInlineQueryChosenChat *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
+	CallbackGame                *CallbackGame                `json:"callback_game,omitempty"`
+}
+
+// hasAction reports whether the button does something other than sending callback data
+func (b InlineKeyboardButton) hasAction() bool {
+	return b.URL != "" || b.WebApp != nil || b.LoginURL != nil ||
+		b.SwitchInlineQuery != nil || b.SwitchInlineQueryCurrentChat != nil ||
+		b.SwitchInlineQueryChosenChat != nil || b.CopyText != nil || b.Pay || b.CallbackGame != nil
+}
+
+// SwitchInlineQueryChosenChat describes a button that starts an inline query in a chat picked by the user
+type SwitchInlineQueryChosenChat struct {
+	Query             string `json:"query,omitempty"`
+	AllowUserChats    bool   `json:"allow_user_chats,omitempty"`
+	AllowBotChats     bool   `json:"allow_bot_chats,omitempty"`
+	AllowGroupChats   bool   `json:"allow_group_chats,omitempty"`
+	AllowChannelChats bool   `json:"allow_channel_chats,omitempty"`
+}
+
+// CallbackGame is a placeholder of the game button, it holds no information
+type CallbackGame struct{}
+
+// WebAppInfo describes a Web App (Mini App) opened by a button
+type WebAppInfo struct {
+	URL string `json:"url"`
+}
+
+// LoginURL describes a button that authorizes the user on a website via Telegram Login
+type LoginURL struct {
+	URL                string `json:"url"`
+	ForwardText        string `json:"forward_text,omitempty"`
+	BotUsername        string `json:"bot_username,omitempty"`
+	RequestWriteAccess bool   `json:"request_write_access,omitempty"`
+}
+
+// CopyTextButton describes a button that copies text to the clipboard
+type CopyTextButton struct {
+	Text string `json:"text"`
 }
 
 // ReplyKeyboardMarkup represents a custom keyboard
 type ReplyKeyboardMarkup struct {
-	Keyboard        [][]KeyboardButton `json:"keyboard"`
-	ResizeKeyboard  bool               `json:"resize_keyboard,omitempty"`
-	OneTimeKeyboard bool               `json:"one_time_keyboard,omitempty"`
+	Keyboard              [][]KeyboardButton `json:"keyboard"`
+	ResizeKeyboard        bool               `json:"resize_keyboard,omitempty"`
+	OneTimeKeyboard       bool               `json:"one_time_keyboard,omitempty"`
+	InputFieldPlaceholder string             `json:"input_field_placeholder,omitempty"`
+	Selective             bool               `json:"selective,omitempty"`
 }
 
 // KeyboardButton represents one button of a reply keyboard
 type KeyboardButton struct {
-	Text            string `json:"text"`
-	RequestContact  bool   `json:"request_contact,omitempty"`
-	RequestLocation bool   `json:"request_location,omitempty"`
+	Text            string                  `json:"text"`
+	RequestContact  bool                    `json:"request_contact,omitempty"`
+	RequestLocation bool                    `json:"request_location,omitempty"`
+	RequestPoll     *KeyboardButtonPollType `json:"request_poll,omitempty"`
+}
+
+// KeyboardButtonPollType limits the poll a request_poll button asks to create
+// Type is "quiz", "regular" or empty to allow any poll
+type KeyboardButtonPollType struct {
+	Type string `json:"type,omitempty"`
 }
 
 // ReplyKeyboardRemove removes custom keyboard
@@ -254,3 +590,98 @@ type ReplyKeyboardRemove struct {
 	RemoveKeyboard bool `json:"remove_keyboard"`
 	Selective      bool `json:"selective,omitempty"`
 }
+
+// LabeledPrice represents a portion of the price for goods or services
+// Amount is in the smallest units of the currency, e.g. cents for USD
+type LabeledPrice struct {
+	Label  string `json:"label"`
+	Amount int    `json:"amount"`
+}
+
+// ShippingAddress represents a shipping address
+type ShippingAddress struct {
+	CountryCode string `json:"country_code"`
+	State       string `json:"state"`
+	City        string `json:"city"`
+	StreetLine1 string `json:"street_line1"`
+	StreetLine2 string `json:"street_line2"`
+	PostCode    string `json:"post_code"`
+}
+
+// OrderInfo represents information about an order
+type OrderInfo struct {
+	Name            string           `json:"name,omitempty"`
+	PhoneNumber     string           `json:"phone_number,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+}
+
+// ShippingOption represents one shipping option offered in AnswerShippingQuery
+type ShippingOption struct {
+	ID     string         `json:"id"`
+	Title  string         `json:"title"`
+	Prices []LabeledPrice `json:"prices"`
+}
+
+// ShippingQuery represents an incoming shipping query of a flexible invoice
+type ShippingQuery struct {
+	ID              string          `json:"id"`
+	From            User            `json:"from"`
+	InvoicePayload  string          `json:"invoice_payload"`
+	ShippingAddress ShippingAddress `json:"shipping_address"`
+}
+
+// PreCheckoutQuery represents an incoming pre-checkout query
+type PreCheckoutQuery struct {
+	ID               string     `json:"id"`
+	From             User       `json:"from"`
+	Currency         string     `json:"currency"`
+	TotalAmount      int        `json:"total_amount"`
+	InvoicePayload   string     `json:"invoice_payload"`
+	ShippingOptionID string     `json:"shipping_option_id,omitempty"`
+	OrderInfo        *OrderInfo `json:"order_info,omitempty"`
+}
+
+// SuccessfulPayment contains information about a successful payment
+type SuccessfulPayment struct {
+	Currency                string     `json:"currency"`
+	TotalAmount             int        `json:"total_amount"`
+	InvoicePayload          string     `json:"invoice_payload"`
+	ShippingOptionID        string     `json:"shipping_option_id,omitempty"`
+	OrderInfo               *OrderInfo `json:"order_info,omitempty"`
+	TelegramPaymentChargeID string     `json:"telegram_payment_charge_id"`
+	ProviderPaymentChargeID string     `json:"provider_payment_charge_id"`
+}
+
+// ForumTopic represents a forum topic
+type ForumTopic struct {
+	MessageThreadID   int64  `json:"message_thread_id"`
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ChatInviteLink represents an invite link for a chat
+type ChatInviteLink struct {
+	InviteLink              string `json:"invite_link"`
+	Creator                 *User  `json:"creator"`
+	CreatesJoinRequest      bool   `json:"creates_join_request"`
+	IsPrimary               bool   `json:"is_primary"`
+	IsRevoked               bool   `json:"is_revoked"`
+	Name                    string `json:"name,omitempty"`
+	ExpireDate              int64  `json:"expire_date,omitempty"`                // Unix time
+	MemberLimit             int    `json:"member_limit,omitempty"`               // Max members joining via the link
+	PendingJoinRequestCount int    `json:"pending_join_request_count,omitempty"` // Join requests waiting for approval
+}
+
+// WebhookInfo represents the current state of the webhook
+type WebhookInfo struct {
+	URL                  string   `json:"url"`
+	HasCustomCertificate bool     `json:"has_custom_certificate"`
+	PendingUpdateCount   int      `json:"pending_update_count"`
+	IPAddress            string   `json:"ip_address,omitempty"`
+	LastErrorDate        int64    `json:"last_error_date,omitempty"`
+	LastErrorMessage     string   `json:"last_error_message,omitempty"`
+	MaxConnections       int      `json:"max_connections,omitempty"`
+	AllowedUpdates       []string `json:"allowed_updates,omitempty"`
+}
@@ -1,18 +1,104 @@
 package telegram
 
 import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
 	"crypto/sha1"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	// maxCallbackDataLen is the max length of callback_data allowed by Telegram
+	maxCallbackDataLen = 64
+
+	// callbackDataVersion prefixes encoded callback data to allow changing the format later
+	callbackDataVersion = '1'
+
+	// Payload encodings of callback data
+	callbackDataJSON     = 'j'
+	callbackDataDeflated = 'z'
+)
+
+// ErrCallbackDataTooLong is returned when an encoded payload doesn't fit into callback_data
+var ErrCallbackDataTooLong = errors.New("encoded callback data exceeds 64 bytes")
+
+// callbackHashCounter makes every generated callback hash unique within the process
+var callbackHashCounter uint64
+
 // GenerateCallbackHash generates unique hash for callback data
+// Uniqueness doesn't depend on clock resolution: the input includes a process-wide
+// counter and random bytes, so hashes generated in the same tick never collide
 func GenerateCallbackHash(index int) string {
-	buf := make([]byte, 8)
-	binary.BigEndian.PutUint64(buf, uint64(time.Now().UnixNano())^uint64(index))
+	buf := make([]byte, 32)
+	binary.BigEndian.PutUint64(buf[0:], atomic.AddUint64(&callbackHashCounter, 1))
+	binary.BigEndian.PutUint64(buf[8:], uint64(time.Now().UnixNano()))
+	binary.BigEndian.PutUint64(buf[16:], uint64(index))
+	// crypto/rand never fails on supported platforms, the counter keeps hashes unique anyway
+	_, _ = rand.Read(buf[24:])
 
 	hash := sha1.New()
 	hash.Write(buf)
 	return hex.EncodeToString(hash.Sum(nil))
 }
+
+// EncodeCallbackData packs payload into self-contained callback_data
+// Unlike GenerateCallbackHash it doesn't require storing the payload anywhere,
+// decode it back with DecodeCallbackData. The payload is JSON encoded and
+// deflated when that makes it shorter, the result must fit into 64 bytes
+func EncodeCallbackData(payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode callback data: %w", err)
+	}
+
+	encoding := byte(callbackDataJSON)
+	var deflated bytes.Buffer
+	w, _ := flate.NewWriter(&deflated, flate.BestCompression)
+	if _, err := w.Write(data); err == nil && w.Close() == nil && deflated.Len() < len(data) {
+		encoding = callbackDataDeflated
+		data = deflated.Bytes()
+	}
+
+	encoded := string([]byte{callbackDataVersion, encoding}) + base64.RawURLEncoding.EncodeToString(data)
+	if len(encoded) > maxCallbackDataLen {
+		return "", fmt.Errorf("%w: %d bytes", ErrCallbackDataTooLong, len(encoded))
+	}
+	return encoded, nil
+}
+
+// DecodeCallbackData unpacks callback_data created by EncodeCallbackData into out
+func DecodeCallbackData(data string, out interface{}) error {
+	if len(data) < 2 || data[0] != callbackDataVersion {
+		return errors.New("unsupported callback data format")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(data[2:])
+	if err != nil {
+		return fmt.Errorf("failed to decode callback data: %w", err)
+	}
+
+	switch data[1] {
+	case callbackDataJSON:
+	case callbackDataDeflated:
+		raw, err = io.ReadAll(flate.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			return fmt.Errorf("failed to inflate callback data: %w", err)
+		}
+	default:
+		return errors.New("unsupported callback data encoding")
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode callback data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGenerateCallbackHashUniqueConcurrent(t *testing.T) {
+	const (
+		goroutines = 50
+		perWorker  = 2000 // 100k hashes in total
+	)
+
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			hashes := make([]string, perWorker)
+			for i := range hashes {
+				// The same index in every goroutine, only the generator keeps them apart
+				hashes[i] = GenerateCallbackHash(i)
+			}
+			results[g] = hashes
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[string]struct{}, goroutines*perWorker)
+	for _, hashes := range results {
+		for _, h := range hashes {
+			if _, ok := seen[h]; ok {
+				t.Fatalf("duplicate hash %s", h)
+			}
+			seen[h] = struct{}{}
+		}
+	}
+	if len(seen) != goroutines*perWorker {
+		t.Fatalf("got %d unique hashes, want %d", len(seen), goroutines*perWorker)
+	}
+}
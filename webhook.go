@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxWebhookBodySize limits the size of an incoming webhook update
+	maxWebhookBodySize = 1 << 20
+
+	// SecretTokenHeader is the header with the secret_token passed to SetWebhook
+	SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+)
+
+var (
+	// ErrWebhookBodyTooLarge is returned when a webhook request body exceeds the size limit
+	ErrWebhookBodyTooLarge = errors.New("webhook request body is too large")
+
+	// ErrWebhookMalformed is returned when a webhook request body is not a valid update
+	ErrWebhookMalformed = errors.New("webhook request body is malformed")
+)
+
+// ParseWebhookRequest reads and decodes an update from an incoming webhook request
+func ParseWebhookRequest(r *http.Request) (*Update, error) {
+	if r.Body == nil {
+		return nil, fmt.Errorf("%w: empty body", ErrWebhookMalformed)
+	}
+	defer r.Body.Close()
+
+	// Read one byte over the limit to detect oversized bodies
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook request body: %w", err)
+	}
+	if len(body) > maxWebhookBodySize {
+		return nil, ErrWebhookBodyTooLarge
+	}
+
+	return ParseWebhookUpdate(body)
+}
+
+// ParseWebhookUpdate decodes an update from raw webhook JSON
+func ParseWebhookUpdate(body []byte) (*Update, error) {
+	if len(body) > maxWebhookBodySize {
+		return nil, ErrWebhookBodyTooLarge
+	}
+
+	var update Update
+	if err := json.Unmarshal(body, &update); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookMalformed, err)
+	}
+	if update.UpdateID == 0 {
+		return nil, fmt.Errorf("%w: missing update_id", ErrWebhookMalformed)
+	}
+
+	return &update, nil
+}
+
+// ValidateWebhookSecret checks that the request carries the secret_token set by SetWebhook
+// The header is compared in constant time. An empty secret fails every request,
+// so a missing configuration value doesn't silently turn authentication off
+func ValidateWebhookSecret(r *http.Request, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	header := r.Header.Get(SecretTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(header), []byte(secret)) == 1
+}
+
+// WebhookHandler returns an http.Handler that receives webhook updates and passes them to fn
+// Requests with a wrong secret get 401, as all requests do if secret is empty,
+// malformed updates get 400. Valid updates are acknowledged with 200 before fn
+// is called with the request context
+func (c *Client) WebhookHandler(secret string, fn func(ctx context.Context, u *Update)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !ValidateWebhookSecret(r, secret) {
+			if c.logger != nil {
+				c.logger.Warn("webhook request with invalid secret token",
+					zap.String("remote_addr", r.RemoteAddr),
+				)
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		update, err := ParseWebhookRequest(r)
+		if err != nil {
+			if c.logger != nil {
+				c.logger.Warn("failed to parse webhook update", zap.Error(err))
+			}
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrWebhookBodyTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, "bad request", status)
+			return
+		}
+
+		// Acknowledge the update right away so Telegram doesn't redeliver it
+		w.WriteHeader(http.StatusOK)
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		fn(r.Context(), update)
+	})
+}
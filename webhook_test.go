@@ -0,0 +1,32 @@
+package telegram
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateWebhookSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		secret string
+		want   bool
+	}{
+		{"match", "s3cret", "s3cret", true},
+		{"mismatch", "other", "s3cret", false},
+		{"missing header", "", "s3cret", false},
+		{"empty secret", "", "", false},
+		{"empty secret with header", "anything", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/tg", nil)
+			if tt.header != "" {
+				r.Header.Set(SecretTokenHeader, tt.header)
+			}
+			if got := ValidateWebhookSecret(r, tt.secret); got != tt.want {
+				t.Errorf("ValidateWebhookSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}